@@ -0,0 +1,149 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/output"
+)
+
+const latestModelReference = "latest"
+
+// readModelReference reads a model from a file if reference is the path of an existing file,
+// and otherwise from the store, taking reference as a model ID (or "latest").
+func readModelReference(
+	ctx context.Context,
+	clientConfig fga.ClientConfig,
+	reference string,
+	format authorizationmodel.ModelFormat,
+) (*authorizationmodel.AuthzModel, error) {
+	authModel := &authorizationmodel.AuthzModel{}
+
+	if _, err := os.Stat(reference); err == nil {
+		var input, storeName string
+
+		if err := authorizationmodel.ReadFromFile(reference, &input, &format, &storeName); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		if err := authModel.ReadModelFromString(input, format); err != nil {
+			return nil, fmt.Errorf("failed to read model from %s due to %w", reference, err)
+		}
+
+		return authModel, nil
+	}
+
+	if clientConfig.StoreID == "" {
+		return nil, clierrors.ValidationError( //nolint:wrapcheck
+			"model diff",
+			fmt.Sprintf("%q is not a file, --store-id is required to read it as a model ID", reference))
+	}
+
+	clientConfig.AuthorizationModelID = reference
+	if reference == latestModelReference {
+		clientConfig.AuthorizationModelID = ""
+	}
+
+	fgaClient, err := clientConfig.GetFgaClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize FGA Client due to %w", err)
+	}
+
+	response, err := authorizationmodel.ReadFromStore(ctx, clientConfig, fgaClient)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	authModel.Set(*response.AuthorizationModel)
+
+	return authModel, nil
+}
+
+// diffCmd represents the model diff command.
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two Authorization Models",
+	Long: `Compare two authorization models and report the types, relations and conditions that were added,
+removed or changed. For changed relations, changes to the rewrite and to the directly assignable types are
+reported separately.
+
+--from and --to each take either a model file (fga, json or fga.mod), or a model ID of the store
+(or "latest" for its latest model).`,
+	Example: `fga model diff --from model.fga --to new-model.fga
+fga model diff --store-id=01H0H015178Y2V4CX10C2KGHF4 --from 01GXSA8YR785C4FYS3C0RTG7B1 --to model.fga
+fga model diff --store-id=01H0H015178Y2V4CX10C2KGHF4 --from latest --to fga.mod --output-format json`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		clientConfig := cmdutils.GetClientConfig(cmd)
+
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		outputFormat, _ := cmd.Flags().GetString("output-format")
+
+		if outputFormat != "text" && outputFormat != "json" {
+			return clierrors.ValidationError( //nolint:wrapcheck
+				"model diff", fmt.Sprintf(`unsupported output format %q, supported formats are "text" and "json"`, outputFormat))
+		}
+
+		fromModel, err := readModelReference(cmd.Context(), clientConfig, from, diffInputFormat)
+		if err != nil {
+			return err
+		}
+
+		toModel, err := readModelReference(cmd.Context(), clientConfig, to, diffInputFormat)
+		if err != nil {
+			return err
+		}
+
+		diff, err := authorizationmodel.Diff(fromModel, toModel)
+		if err != nil {
+			return fmt.Errorf("failed to diff models due to %w", err)
+		}
+
+		if outputFormat == "json" {
+			return output.Display(diff) //nolint:wrapcheck
+		}
+
+		fmt.Println(diff.FriendlyDisplay())
+
+		return nil
+	},
+}
+
+var diffInputFormat = authorizationmodel.ModelFormatDefault
+
+func init() {
+	diffCmd.Flags().String("from", "", `Model file, model ID or "latest" to compare from`)
+	diffCmd.Flags().String("to", "", `Model file, model ID or "latest" to compare to`)
+	diffCmd.Flags().Var(&diffInputFormat, "format", `Authorization model input format of the model files. Can be "fga", "json", or "modular"`) //nolint:lll
+	diffCmd.Flags().String("output-format", "text", `Output format. Can be "text" or "json"`)
+
+	for _, flagName := range []string{"from", "to"} {
+		if err := diffCmd.MarkFlagRequired(flagName); err != nil {
+			fmt.Printf("error setting flag as required - %v: %v\n", "cmd/models/diff", err)
+			os.Exit(1)
+		}
+	}
+}
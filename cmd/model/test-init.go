@@ -0,0 +1,145 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/confirmation"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+// modelFileReference returns the path of modelFile as it should be referenced from the test
+// file, relative to the directory of outputFile (or the working directory when the tests are
+// written to stdout) so that fga model test can locate it.
+func modelFileReference(outputFile string, modelFile string) string {
+	baseDir := "."
+	if outputFile != "" {
+		baseDir = filepath.Dir(outputFile)
+	}
+
+	absBaseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return modelFile
+	}
+
+	absModelFile, err := filepath.Abs(modelFile)
+	if err != nil {
+		return modelFile
+	}
+
+	relative, err := filepath.Rel(absBaseDir, absModelFile)
+	if err != nil {
+		return absModelFile
+	}
+
+	return filepath.ToSlash(relative)
+}
+
+func generateTestSkeleton(
+	modelFile string,
+	format authorizationmodel.ModelFormat,
+	outputFile string,
+) ([]byte, error) {
+	var input, storeName string
+
+	if err := authorizationmodel.ReadFromFile(modelFile, &input, &format, &storeName); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	authModel := authorizationmodel.AuthzModel{}
+	if err := authModel.ReadModelFromString(input, format); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	storeData := storetest.NewStoreSkeleton(&authModel, storeName, modelFileReference(outputFile, modelFile))
+
+	testsYaml, err := yaml.Marshal(storeData)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal storedata yaml: %w", err)
+	}
+
+	return testsYaml, nil
+}
+
+// testInitCmd represents the test init command.
+var testInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Generate a starter test file for an Authorization Model",
+	Long: `Generate a starter test file for an authorization model, to fill in and run with fga model test.
+
+The test file references the model, has a sample tuple for each directly assignable type of each relation, and a check and a list_objects assertion for each relation of each type. The assertions expect no access until their expected results are filled in.`, //nolint:lll
+	Example: `fga model test init --model model.fga
+fga model test init --model fga.mod --output-file model.fga.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		modelFile, _ := cmd.Flags().GetString("model")
+		outputFile, _ := cmd.Flags().GetString("output-file")
+
+		testsYaml, err := generateTestSkeleton(modelFile, testInitInputFormat, outputFile)
+		if err != nil {
+			return err
+		}
+
+		if outputFile == "" {
+			fmt.Print(string(testsYaml))
+
+			return nil
+		}
+
+		if _, err := os.Stat(outputFile); err == nil {
+			confirm, err := confirmation.AskForConfirmation("File exists, overwrite?")
+			if err != nil {
+				return fmt.Errorf("prompt failed due to %w", err)
+			}
+
+			if !confirm {
+				fmt.Println("cancelled")
+
+				return output.Display(output.EmptyStruct{}) //nolint:wrapcheck
+			}
+		}
+
+		if err := os.WriteFile(outputFile, testsYaml, 0o600); err != nil { //nolint:mnd
+			return err //nolint:wrapcheck
+		}
+
+		fmt.Printf("tests written to %s\n", outputFile)
+
+		return nil
+	},
+}
+
+var testInitInputFormat = authorizationmodel.ModelFormatDefault
+
+func init() {
+	testInitCmd.Flags().String("model", "", "Model file, in the JSON or DSL format or an fga.mod file")
+	testInitCmd.Flags().Var(&testInitInputFormat, "format", `Authorization model input format. Can be "fga", "json", or "modular"`) //nolint:lll
+	testInitCmd.Flags().String("output-file", "", "File to write the tests to (defaults to stdout)")
+
+	if err := testInitCmd.MarkFlagRequired("model"); err != nil {
+		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/models/test-init", err)
+		os.Exit(1)
+	}
+}
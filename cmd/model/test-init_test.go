@@ -0,0 +1,54 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+func TestGenerateTestSkeleton(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	modelFile := filepath.Join(dir, "docs.fga")
+	require.NoError(t, os.WriteFile(modelFile, []byte(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`), 0o600))
+
+	testsYaml, err := generateTestSkeleton(modelFile, authorizationmodel.ModelFormatDefault,
+		filepath.Join(dir, "tests", "docs.fga.yaml"))
+	require.NoError(t, err)
+
+	storeData := storetest.StoreData{}
+	require.NoError(t, yaml.Unmarshal(testsYaml, &storeData))
+
+	assert.Equal(t, "docs", storeData.Name)
+	assert.Equal(t, "../docs.fga", storeData.ModelFile)
+	assert.Empty(t, storeData.Model)
+	assert.Len(t, storeData.Tuples, 1)
+	require.Len(t, storeData.Tests, 1)
+	assert.Equal(t, map[string]bool{"viewer": false}, storeData.Tests[0].Check[0].Assertions)
+}
+
+func TestGenerateTestSkeletonInvalidModel(t *testing.T) {
+	t.Parallel()
+
+	modelFile := filepath.Join(t.TempDir(), "invalid.fga")
+	require.NoError(t, os.WriteFile(modelFile, []byte("model\n  schema 1.1\ntype"), 0o600))
+
+	_, err := generateTestSkeleton(modelFile, authorizationmodel.ModelFormatDefault, "")
+	require.Error(t, err)
+}
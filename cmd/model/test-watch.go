@@ -0,0 +1,236 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/requests"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+// watchPollInterval is how often watched files are checked for changes.
+const watchPollInterval = 500 * time.Millisecond
+
+// fileState is what is compared to tell whether a file changed between two polls.
+type fileState struct {
+	exists  bool
+	size    int64
+	modTime time.Time
+}
+
+func statFile(fileName string) fileState {
+	info, err := os.Stat(fileName)
+	if err != nil {
+		return fileState{}
+	}
+
+	return fileState{exists: true, size: info.Size(), modTime: info.ModTime()}
+}
+
+func (state fileState) equal(other fileState) bool {
+	return state.exists == other.exists && state.size == other.size && state.modTime.Equal(other.modTime)
+}
+
+// testWatcher tracks the test files matching a pattern, and the files each of them references.
+type testWatcher struct {
+	testsPattern string
+	// dependencies maps each test file to the files it was read from, including itself
+	dependencies map[string][]string
+	states       map[string]fileState
+}
+
+func newTestWatcher(testsPattern string) *testWatcher {
+	return &testWatcher{
+		testsPattern: testsPattern,
+		dependencies: map[string][]string{},
+		states:       map[string]fileState{},
+	}
+}
+
+// changedTestFiles returns the test files that are new since the last call, or that reference a
+// file that changed since then. Test files that no longer match the pattern stop being watched.
+func (watcher *testWatcher) changedTestFiles() []string {
+	// a file being saved can briefly be missing, it is picked up again on the next poll
+	fileNames, _ := resolveTestFiles(watcher.testsPattern)
+
+	changed := []string{}
+	current := map[string]bool{}
+
+	for _, fileName := range fileNames {
+		current[fileName] = true
+
+		dependencies, known := watcher.dependencies[fileName]
+		if !known {
+			watcher.dependencies[fileName] = []string{fileName}
+			changed = append(changed, fileName)
+
+			continue
+		}
+
+		for _, dependency := range dependencies {
+			if !statFile(dependency).equal(watcher.states[dependency]) {
+				changed = append(changed, fileName)
+
+				break
+			}
+		}
+	}
+
+	for fileName := range watcher.dependencies {
+		if !current[fileName] {
+			delete(watcher.dependencies, fileName)
+		}
+	}
+
+	for _, fileName := range changed {
+		for _, dependency := range watcher.dependencies[fileName] {
+			watcher.states[dependency] = statFile(dependency)
+		}
+	}
+
+	return changed
+}
+
+// setDependencies records the files the test file was read from when its tests last ran.
+func (watcher *testWatcher) setDependencies(fileName string, dependencies []string) {
+	watcher.dependencies[fileName] = dependencies
+
+	for _, dependency := range dependencies {
+		if _, ok := watcher.states[dependency]; !ok {
+			watcher.states[dependency] = statFile(dependency)
+		}
+	}
+}
+
+// runWatchedTestFiles runs the tests of the files against the local server, and records the
+// files each of them references. A file that fails to read or run is reported, and is run again
+// once it, or a file it references, changes.
+func runWatchedTestFiles(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	localServer *storetest.LocalServer,
+	watcher *testWatcher,
+	fileNames []string,
+	allowExternalFiles bool,
+	options storetest.RunOptions,
+) ([]string, []storetest.TestResults) {
+	storeFiles := make([]*storetest.StoreData, len(fileNames))
+	formats := make([]authorizationmodel.ModelFormat, len(fileNames))
+	fileResults := make([]storetest.TestResults, len(fileNames))
+	errs := make([]error, len(fileNames))
+	filesParallel, options := splitParallel(len(fileNames), options)
+
+	_ = requests.RunInParallel(len(fileNames), filesParallel, func(index int) error {
+		file := fileNames[index]
+
+		format, storeData, err := storetest.ReadFromFile(file, "", allowExternalFiles)
+		if err != nil {
+			errs[index] = fmt.Errorf("failed to read test file %s: %w", file, err)
+
+			return nil
+		}
+
+		storeFiles[index] = storeData
+		formats[index] = format
+
+		fileResults[index], err = localServer.RunTests(ctx, fgaClient, storeData, format, options)
+		if err != nil {
+			errs[index] = fmt.Errorf("error running tests for %s due to %w", file, err)
+		}
+
+		return nil
+	})
+
+	ranFiles := []string{}
+	ranResults := []storetest.TestResults{}
+
+	for index, file := range fileNames {
+		if storeFiles[index] != nil {
+			watcher.setDependencies(file, storeFiles[index].ReferencedFiles(file, formats[index]))
+		}
+
+		if errs[index] != nil {
+			fmt.Fprintln(os.Stderr, errs[index])
+
+			continue
+		}
+
+		ranFiles = append(ranFiles, file)
+		ranResults = append(ranResults, fileResults[index])
+	}
+
+	return ranFiles, ranResults
+}
+
+// watchTests runs the tests of the files matching testsPattern, then polls the test files and
+// the files they reference, re-running the tests of a file whenever one of them changes. All runs
+// use the same embedded server. It returns when ctx is done.
+func watchTests(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	testsPattern string,
+	allowExternalFiles bool,
+	serverConfig storetest.LocalServerConfig,
+	options storetest.RunOptions,
+	suppressSummary bool,
+	verbose bool,
+) error {
+	localServer, err := storetest.NewLocalServer(serverConfig)
+	if err != nil {
+		return fmt.Errorf("failed to start the local server due to %w", err)
+	}
+	defer localServer.Close()
+
+	watcher := newTestWatcher(testsPattern)
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if changed := watcher.changedTestFiles(); len(changed) > 0 {
+			fmt.Fprintf(os.Stderr, "\n# %s - running %s #\n", time.Now().Format(time.TimeOnly), strings.Join(changed, ", "))
+
+			ranFiles, fileResults := runWatchedTestFiles(
+				ctx, fgaClient, localServer, watcher, changed, allowExternalFiles, options)
+
+			aggregateResults, err := displayTestResults(ranFiles, fileResults, suppressSummary, verbose)
+			if err != nil {
+				return err
+			}
+
+			if aggregateResults.Coverage != nil && !suppressSummary {
+				fmt.Fprintln(os.Stderr, aggregateResults.Coverage.FriendlyDisplay())
+			}
+
+			fmt.Fprintln(os.Stderr, "# Watching for changes, press Ctrl+C to stop #")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/storetest"
+)
+
+func TestTestWatcherReRunsOnReferencedFileChange(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.fga"), []byte(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tuples.yaml"), []byte(`- user: user:anne
+  relation: viewer
+  object: document:1
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.fga"), []byte("model\n"), 0o600))
+
+	testFile := filepath.Join(dir, "store.fga.yaml")
+	require.NoError(t, os.WriteFile(testFile, []byte(`model_file: model.fga
+tuple_file: tuples.yaml
+tests:
+  - name: viewers
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: true
+`), 0o600))
+
+	watcher := newTestWatcher(filepath.Join(dir, "*.fga.yaml"))
+
+	changed := watcher.changedTestFiles()
+	require.Equal(t, []string{testFile}, changed)
+
+	localServer, err := storetest.NewLocalServer(storetest.LocalServerConfig{MaxTypesPerAuthorizationModel: 100})
+	require.NoError(t, err)
+	t.Cleanup(localServer.Close)
+
+	ranFiles, fileResults := runWatchedTestFiles(t.Context(), nil, localServer, watcher, changed, false,
+		storetest.RunOptions{Parallel: 1})
+	require.Equal(t, []string{testFile}, ranFiles)
+	assert.True(t, fileResults[0].IsPassing())
+
+	assert.Equal(t, []string{
+		testFile, filepath.Join(dir, "model.fga"), filepath.Join(dir, "tuples.yaml"),
+	}, watcher.dependencies[testFile])
+	assert.Empty(t, watcher.changedTestFiles())
+
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "unrelated.fga"), later, later))
+	assert.Empty(t, watcher.changedTestFiles())
+
+	require.NoError(t, os.Chtimes(filepath.Join(dir, "tuples.yaml"), later, later))
+	assert.Equal(t, []string{testFile}, watcher.changedTestFiles())
+	assert.Empty(t, watcher.changedTestFiles())
+}
@@ -17,17 +17,20 @@ limitations under the License.
 package model
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/openfga/go-sdk/client"
 	"github.com/spf13/cobra"
 
 	"github.com/openfga/cli/internal/clierrors"
 	"github.com/openfga/cli/internal/cmdutils"
 	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/requests"
 	"github.com/openfga/cli/internal/storetest"
 )
 
@@ -41,10 +44,12 @@ var errNoTestFilesMatched = errors.New("no test files matched pattern")
 
 // modelTestCmd represents the test command.
 var modelTestCmd = &cobra.Command{
-	Use:     "test",
-	Short:   "Test an Authorization Model",
-	Long:    "Run a set of tests against a particular Authorization Model.",
-	Example: `fga model test --tests model.fga.yaml`,
+	Use:   "test",
+	Short: "Test an Authorization Model",
+	Long:  "Run a set of tests against a particular Authorization Model.",
+	Example: `fga model test --tests model.fga.yaml
+fga model test --tests "tests/*.fga.yaml" --report-format junit --report-file report.xml
+fga model test --tests "tests/*.fga.yaml" --watch`,
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		// Read and validate all flags
 		testsFileName, err := cmd.Flags().GetString("tests")
@@ -72,6 +77,20 @@ var modelTestCmd = &cobra.Command{
 			return fmt.Errorf("failed to get max-types-per-authorization-model flag: %w", err)
 		}
 
+		parallel, err := cmd.Flags().GetInt("parallel")
+		if err != nil {
+			return fmt.Errorf("failed to get parallel flag: %w", err)
+		}
+
+		if parallel <= 0 {
+			return clierrors.ValidationError("model test", "parallel must be greater than 0")
+		}
+
+		coverageFile, _ := cmd.Flags().GetString("coverage-file")
+		minCoverage, _ := cmd.Flags().GetFloat64("min-coverage")
+		coverage, _ := cmd.Flags().GetBool("coverage")
+		coverage = coverage || coverageFile != "" || cmd.Flags().Changed("min-coverage")
+
 		if maxTypes <= 0 {
 			return clierrors.ValidationError("model test",
 				"max-types-per-authorization-model must be greater than 0")
@@ -86,8 +105,6 @@ var modelTestCmd = &cobra.Command{
 			return err
 		}
 
-		multipleFiles := len(fileNames) > 1
-
 		clientConfig := cmdutils.GetClientConfig(cmd)
 
 		fgaClient, err := clientConfig.GetFgaClient()
@@ -95,78 +112,254 @@ var modelTestCmd = &cobra.Command{
 			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
 		}
 
-		aggregateResults := storetest.TestResults{}
-		summaries := []string{}
+		options := storetest.RunOptions{Parallel: parallel, Coverage: coverage}
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			return watchTests(cmd.Context(), fgaClient, testsFileName, allowExternalFiles, serverConfig, options,
+				suppressSummary, verbose)
+		}
+
+		fileResults, err := runTestFiles(cmd.Context(), fgaClient, fileNames, allowExternalFiles, serverConfig, options)
+		if err != nil {
+			return err
+		}
 
-		for _, file := range fileNames {
-			format, storeData, err := storetest.ReadFromFile(file, "", allowExternalFiles)
+		aggregateResults, err := displayTestResults(fileNames, fileResults, suppressSummary, verbose)
+		if err != nil {
+			return err
+		}
+
+		passing := aggregateResults.IsPassing()
+
+		reportFile, _ := cmd.Flags().GetString("report-file")
+		if err := writeTestReport(aggregateResults, testReportFormat, reportFile); err != nil {
+			return err
+		}
+
+		if coverage {
+			coveragePassing, err := reportCoverage(
+				aggregateResults.Coverage, testsFileName, coverageFile, minCoverage, suppressSummary)
 			if err != nil {
-				return fmt.Errorf("failed to read test file %s: %w", file, err)
+				return err
 			}
 
-			test, err := storetest.RunTests(
-				cmd.Context(),
-				fgaClient,
-				storeData,
-				format,
-				serverConfig,
-			)
-			if err != nil {
-				return fmt.Errorf("error running tests for %s due to %w", file, err)
+			passing = passing && coveragePassing
+		}
+
+		if !passing {
+			os.Exit(1)
+		}
+
+		return nil
+	},
+}
+
+// displayTestResults prints the summary of each file (when there are several) and of all the
+// results to stderr, and the results as JSON if verbose is set. It returns the aggregated results.
+func displayTestResults(
+	fileNames []string,
+	fileResults []storetest.TestResults,
+	suppressSummary bool,
+	verbose bool,
+) (storetest.TestResults, error) {
+	multipleFiles := len(fileNames) > 1
+	aggregateResults := storetest.TestResults{}
+	summaries := []string{}
+
+	for index, file := range fileNames {
+		test := fileResults[index]
+
+		aggregateResults.Results = append(aggregateResults.Results, test.Results...)
+
+		if test.Coverage != nil {
+			if aggregateResults.Coverage == nil {
+				aggregateResults.Coverage = &storetest.Coverage{}
 			}
 
-			aggregateResults.Results = append(aggregateResults.Results, test.Results...)
+			aggregateResults.Coverage.Merge(test.Coverage)
+		}
 
-			if !suppressSummary && multipleFiles {
-				fullDisplay := test.FriendlyDisplay()
+		if !suppressSummary && multipleFiles {
+			fullDisplay := test.FriendlyDisplay()
 
-				// Extract just the summary part (after "# Test Summary #")
-				headerIndex := strings.Index(fullDisplay, "# Test Summary #")
+			// Extract just the summary part (after "# Test Summary #")
+			headerIndex := strings.Index(fullDisplay, "# Test Summary #")
 
-				var summaryText string
+			var summaryText string
 
-				if headerIndex != -1 {
-					// Get the summary part and remove the "# Test Summary #" header
-					summaryPart := fullDisplay[headerIndex:]
-					lines := strings.Split(summaryPart, "\n")
+			if headerIndex != -1 {
+				// Get the summary part and remove the "# Test Summary #" header
+				summaryPart := fullDisplay[headerIndex:]
+				lines := strings.Split(summaryPart, "\n")
 
-					if len(lines) > 1 {
-						summaryText = strings.Join(lines[1:], "\n") // Skip the header line
-					}
-				} else {
-					summaryText = fullDisplay
+				if len(lines) > 1 {
+					summaryText = strings.Join(lines[1:], "\n") // Skip the header line
 				}
+			} else {
+				summaryText = fullDisplay
+			}
+
+			summary := fmt.Sprintf("# file: %s\n%s", file, summaryText)
+			summaries = append(summaries, summary)
+		}
+	}
 
-				summary := fmt.Sprintf("# file: %s\n%s", file, summaryText)
-				summaries = append(summaries, summary)
+	if !suppressSummary {
+		if multipleFiles {
+			for _, summary := range summaries {
+				fmt.Fprintln(os.Stderr, summary)
 			}
 		}
 
-		passing := aggregateResults.IsPassing()
+		fmt.Fprintln(os.Stderr, aggregateResults.FriendlyDisplay())
+	}
 
-		if !suppressSummary {
-			if multipleFiles {
-				for _, summary := range summaries {
-					fmt.Fprintln(os.Stderr, summary)
-				}
-			}
+	if verbose {
+		if err := output.Display(aggregateResults.Results); err != nil {
+			return aggregateResults, fmt.Errorf("error displaying test results due to %w", err)
+		}
+	}
 
-			fmt.Fprintln(os.Stderr, aggregateResults.FriendlyDisplay())
+	return aggregateResults, nil
+}
+
+// splitParallel applies options.Parallel at one level only: to the files when there are several,
+// running the tests of each file one at a time, and to the tests of the file otherwise. It returns
+// the number of files to run at a time, and the options to run the tests of each file with.
+func splitParallel(fileCount int, options storetest.RunOptions) (int, storetest.RunOptions) {
+	if fileCount <= 1 {
+		return 1, options
+	}
+
+	filesParallel := options.Parallel
+	options.Parallel = 1
+
+	return filesParallel, options
+}
+
+// runTestFiles runs the tests of each file, with up to options.Parallel tests running at a time,
+// see splitParallel. Results are returned in the order of fileNames.
+func runTestFiles(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	fileNames []string,
+	allowExternalFiles bool,
+	serverConfig storetest.LocalServerConfig,
+	options storetest.RunOptions,
+) ([]storetest.TestResults, error) {
+	fileResults := make([]storetest.TestResults, len(fileNames))
+	filesParallel, options := splitParallel(len(fileNames), options)
+
+	err := requests.RunInParallel(len(fileNames), filesParallel, func(index int) error {
+		file := fileNames[index]
+
+		format, storeData, err := storetest.ReadFromFile(file, "", allowExternalFiles)
+		if err != nil {
+			return fmt.Errorf("failed to read test file %s: %w", file, err)
 		}
 
-		if verbose {
-			err = output.Display(aggregateResults.Results)
-			if err != nil {
-				return fmt.Errorf("error displaying test results due to %w", err)
-			}
+		test, err := storetest.RunTests(ctx, fgaClient, storeData, format, serverConfig, options)
+		if err != nil {
+			return fmt.Errorf("error running tests for %s due to %w", file, err)
 		}
 
-		if !passing {
-			os.Exit(1)
+		fileResults[index] = test
+
+		return nil
+	})
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return fileResults, nil
+}
+
+// reportCoverage prints the coverage unless suppressSummary is set, writes it to coverageFile
+// (as JSON for a .json file, and in an LCOV-like format otherwise), and reports whether it meets
+// minCoverage. Coverage is nil when no test ran against a local model.
+func reportCoverage(
+	coverage *storetest.Coverage,
+	testsFileName string,
+	coverageFile string,
+	minCoverage float64,
+	suppressSummary bool,
+) (bool, error) {
+	if coverage == nil {
+		fmt.Fprintln(os.Stderr, "# Coverage #\nNo coverage collected: coverage requires tests with a model")
+
+		return minCoverage <= 0, nil
+	}
+
+	if !suppressSummary {
+		fmt.Fprintln(os.Stderr, coverage.FriendlyDisplay())
+	}
+
+	if coverageFile != "" {
+		file, err := os.Create(coverageFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to create coverage file %s due to %w", coverageFile, err)
 		}
+		defer file.Close()
 
+		if strings.HasSuffix(coverageFile, ".json") {
+			err = coverage.WriteJSON(file)
+		} else {
+			err = coverage.WriteLCOV(file, testsFileName)
+		}
+
+		if err != nil {
+			return false, err //nolint:wrapcheck
+		}
+
+		if err := file.Close(); err != nil {
+			return false, fmt.Errorf("failed to write coverage file %s due to %w", coverageFile, err)
+		}
+	}
+
+	percentage := coverage.Summary().Percentage
+	if percentage < minCoverage {
+		fmt.Fprintf(os.Stderr, "Coverage %.1f%% is below the minimum of %.1f%%\n", percentage, minCoverage)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// writeTestReport writes the results in the requested format to reportFile, or to stdout if
+// no file is given. Without --report-format, the format is taken from the extension of the file.
+func writeTestReport(results storetest.TestResults, format storetest.ReportFormat, reportFile string) error {
+	if format == storetest.ReportFormatNone && reportFile == "" {
 		return nil
-	},
+	}
+
+	if format == storetest.ReportFormatNone {
+		format = storetest.ReportFormatFromFileName(reportFile)
+		if format == storetest.ReportFormatNone {
+			return clierrors.ValidationError("model test", //nolint:wrapcheck
+				"cannot infer the report format from "+reportFile+", set --report-format")
+		}
+	}
+
+	if reportFile == "" {
+		return results.WriteReport(os.Stdout, format) //nolint:wrapcheck
+	}
+
+	file, err := os.Create(reportFile)
+	if err != nil {
+		return fmt.Errorf("failed to create report file %s due to %w", reportFile, err)
+	}
+	defer file.Close()
+
+	if err := results.WriteReport(file, format); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report file %s due to %w", reportFile, err)
+	}
+
+	return nil
 }
 
 // resolveTestFiles turns testsPattern into the list of test files to read.
@@ -222,15 +415,26 @@ func resolveTestFiles(testsPattern string) ([]string, error) {
 	return regularFileNames, nil
 }
 
+var testReportFormat = storetest.ReportFormatNone
+
 func init() {
+	modelTestCmd.AddCommand(testInitCmd)
+
 	modelTestCmd.Flags().String("store-id", "", "Store ID")
 	modelTestCmd.Flags().String("model-id", "", "Model ID")
 	modelTestCmd.Flags().String("tests", "", "Path or glob of YAML test files")
 	modelTestCmd.Flags().Bool("verbose", false, "Print verbose JSON output")
 	modelTestCmd.Flags().Bool("suppress-summary", false, "Suppress the plain text summary output")
+	modelTestCmd.Flags().Var(&testReportFormat, "report-format", `Test report format. Can be "junit", "tap" or "json"`)
+	modelTestCmd.Flags().String("report-file", "", "File to write the test report to (defaults to stdout when --report-format is set)")                      //nolint:lll
+	modelTestCmd.Flags().Bool("coverage", false, "Report the relations and condition outcomes of the model exercised by the tests")                          //nolint:lll
+	modelTestCmd.Flags().String("coverage-file", "", "File to write the coverage to, as JSON for a .json file and LCOV-like otherwise (implies --coverage)") //nolint:lll
+	modelTestCmd.Flags().Float64("min-coverage", 0, "Fail if the total coverage percentage is below this value (implies --coverage)")                        //nolint:lll
+	modelTestCmd.Flags().Bool("watch", false, "Re-run the tests of a file whenever it, or a model or tuple file it references, changes")                     //nolint:lll
+	modelTestCmd.Flags().Int("parallel", 1, "Number of test files, or of tests of a single test file, to run concurrently")
 	modelTestCmd.Flags().Int("max-types-per-authorization-model", 100, //nolint:mnd
 		"Max allowed number of type definitions per authorization model")
-	modelTestCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file and tuple_files references in the test file to resolve to paths outside the test file's directory. Only enable this for test files you trust.") //nolint:lll
+	modelTestCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the test file to resolve to paths outside the test file's directory. Only enable this for test files you trust.") //nolint:lll
 
 	if err := modelTestCmd.MarkFlagRequired("tests"); err != nil {
 		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/models/test", err)
@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/openfga/cli/internal/storetest"
 )
 
 // writeRegularFile writes a minimal regular test file at path. Shared by both this file and
@@ -55,3 +57,17 @@ func TestResolveTestFilesLiteralExistingPath(t *testing.T) {
 		t.Fatalf("expected [%s], got %v", path, fileNames)
 	}
 }
+
+func TestSplitParallel(t *testing.T) {
+	t.Parallel()
+
+	filesParallel, options := splitParallel(3, storetest.RunOptions{Parallel: 8, Coverage: true})
+	if filesParallel != 8 || options.Parallel != 1 || !options.Coverage {
+		t.Fatalf("expected 8 files and 1 test at a time, got %d files and %+v", filesParallel, options)
+	}
+
+	filesParallel, options = splitParallel(1, storetest.RunOptions{Parallel: 8})
+	if filesParallel != 1 || options.Parallel != 8 {
+		t.Fatalf("expected 1 file and 8 tests at a time, got %d files and %+v", filesParallel, options)
+	}
+}
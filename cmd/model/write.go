@@ -18,6 +18,7 @@ package model
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -26,11 +27,20 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
 	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
 	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/tuple"
 	"github.com/openfga/cli/internal/utils"
 )
 
+var errIncompatibleModel = errors.New(
+	"the model is not compatible with the latest model of the store and was not written")
+
+// defaultScanMaxPages is the number of pages of tuples read by --scan-tuples by default.
+const defaultScanMaxPages = 20
+
 func Write(
 	ctx context.Context,
 	fgaClient client.SdkClient,
@@ -50,13 +60,121 @@ func Write(
 	return model, nil
 }
 
+type invalidTuple struct {
+	Tuple  openfga.TupleKey `json:"tuple"`
+	Reason string           `json:"reason"`
+}
+
+// tupleScan holds the tuples of the store that would be invalid with the new model.
+// Complete is false when only the first pages of tuples were scanned.
+type tupleScan struct {
+	ScannedCount  int            `json:"scanned_count"`
+	InvalidCount  int            `json:"invalid_count"`
+	Complete      bool           `json:"complete"`
+	InvalidTuples []invalidTuple `json:"invalid_tuples"`
+}
+
+type compatibilityReport struct {
+	Compatible      bool                                `json:"compatible"`
+	BreakingChanges []authorizationmodel.BreakingChange `json:"breaking_changes"`
+	Tuples          *tupleScan                          `json:"tuples,omitempty"`
+}
+
+type writeResponse struct {
+	AuthorizationModelID string               `json:"authorization_model_id,omitempty"`
+	Compatibility        *compatibilityReport `json:"compatibility"`
+}
+
+// checkCompatibility compares the model with the latest model of the store. If scanTuples
+// is set, up to maxPages pages of the tuples of the store are also validated against the model.
+func checkCompatibility(
+	ctx context.Context,
+	clientConfig fga.ClientConfig,
+	fgaClient client.SdkClient,
+	authModel *authorizationmodel.AuthzModel,
+	scanTuples bool,
+	maxPages int,
+) (*compatibilityReport, error) {
+	report := &compatibilityReport{BreakingChanges: []authorizationmodel.BreakingChange{}}
+
+	clientConfig.AuthorizationModelID = ""
+
+	latestModel := &authorizationmodel.AuthzModel{}
+
+	response, err := authorizationmodel.ReadFromStore(ctx, clientConfig, fgaClient)
+	if err != nil && !errors.Is(err, clierrors.ErrAuthorizationModelNotFound) {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if response != nil {
+		latestModel.Set(*response.AuthorizationModel)
+
+		diff, err := authorizationmodel.Diff(latestModel, authModel)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare with the latest model due to %w", err)
+		}
+
+		report.BreakingChanges = diff.BreakingChanges()
+	}
+
+	if scanTuples {
+		report.Tuples, err = scanInvalidTuples(ctx, fgaClient, authModel, maxPages)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.Compatible = len(report.BreakingChanges) == 0 && (report.Tuples == nil || report.Tuples.InvalidCount == 0)
+
+	return report, nil
+}
+
+func scanInvalidTuples(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	authModel *authorizationmodel.AuthzModel,
+	maxPages int,
+) (*tupleScan, error) {
+	scan := &tupleScan{InvalidTuples: []invalidTuple{}}
+
+	// the scan is complete only if the last page read has no continuation token after it
+	lastContinuationToken := ""
+
+	err := tuple.ReadPages(ctx, fgaClient, &client.ClientReadRequest{}, maxPages, tuple.MaxReadPageSize, nil, "",
+		func(page []openfga.Tuple, continuationToken string) error {
+			scan.ScannedCount += len(page)
+			lastContinuationToken = continuationToken
+
+			for _, t := range page {
+				if err := authModel.ValidateTuple(t.GetKey()); err != nil {
+					scan.InvalidTuples = append(scan.InvalidTuples, invalidTuple{Tuple: t.GetKey(), Reason: err.Error()})
+				}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan tuples due to %w", err)
+	}
+
+	scan.Complete = lastContinuationToken == ""
+	scan.InvalidCount = len(scan.InvalidTuples)
+
+	return scan, nil
+}
+
 // writeCmd represents the write command.
 var writeCmd = &cobra.Command{
 	Use:   "write",
 	Short: "Write Authorization Model",
-	Long:  "Writes a new authorization model.",
+	Long: `Writes a new authorization model.
+
+With --check-compatibility, the model is first compared with the latest model of the store, and it is
+not written if it removes a type, a relation or a directly assignable type, as existing tuples could
+depend on them. With --scan-tuples, the tuples of the store are also read and checked against the model.`,
 	Example: `fga model write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=model.json
 fga model write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=fga.mod
+fga model write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=model.fga --check-compatibility --scan-tuples
 fga model write --store-id=01H0H015178Y2V4CX10C2KGHF4 '{"type_definitions":[{"type":"user"},{"type":"document","relations":{"can_view":{"this":{}}},"metadata":{"relations":{"can_view":{"directly_related_user_types":[{"type":"user"}]}}}}],"schema_version":"1.1"}' --format=json`, //nolint:lll
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -90,12 +208,39 @@ fga model write --store-id=01H0H015178Y2V4CX10C2KGHF4 '{"type_definitions":[{"ty
 
 		ctx := utils.WithDebugContext(cmd.Context(), debug)
 
+		checkCompatibilityFlag, _ := cmd.Flags().GetBool("check-compatibility")
+		scanTuples, _ := cmd.Flags().GetBool("scan-tuples")
+		scanMaxPages, _ := cmd.Flags().GetInt("scan-max-pages")
+
+		if !checkCompatibilityFlag && !scanTuples {
+			response, err := Write(ctx, fgaClient, authModel)
+			if err != nil {
+				return err
+			}
+
+			return output.Display(*response) //nolint:wrapcheck
+		}
+
+		report, err := checkCompatibility(ctx, clientConfig, fgaClient, &authModel, scanTuples, scanMaxPages)
+		if err != nil {
+			return fmt.Errorf("failed to check compatibility due to %w", err)
+		}
+
+		if !report.Compatible {
+			if err := output.Display(writeResponse{Compatibility: report}); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			return errIncompatibleModel
+		}
+
 		response, err := Write(ctx, fgaClient, authModel)
 		if err != nil {
 			return err
 		}
 
-		return output.Display(*response)
+		return output.Display( //nolint:wrapcheck
+			writeResponse{AuthorizationModelID: response.AuthorizationModelId, Compatibility: report})
 	},
 }
 
@@ -104,7 +249,10 @@ var writeInputFormat = authorizationmodel.ModelFormatDefault
 func init() {
 	writeCmd.Flags().String("store-id", "", "Store ID")
 	writeCmd.Flags().String("file", "", "File Name. The file should have the model in the JSON or DSL format")
-	writeCmd.Flags().Var(&writeInputFormat, "format", `Authorization model input format. Can be "fga", "json", or "modular"`) //nolint:lll
+	writeCmd.Flags().Var(&writeInputFormat, "format", `Authorization model input format. Can be "fga", "json", or "modular"`)                                     //nolint:lll
+	writeCmd.Flags().Bool("check-compatibility", false, "Do not write the model if it removes types, relations or directly assignable types of the latest model") //nolint:lll
+	writeCmd.Flags().Bool("scan-tuples", false, "Also check the tuples of the store against the model (implies --check-compatibility)")                           //nolint:lll
+	writeCmd.Flags().Int("scan-max-pages", defaultScanMaxPages, "Max number of pages of tuples to scan with --scan-tuples (0 scans all tuples)")                  //nolint:lll
 
 	if err := writeCmd.MarkFlagRequired("store-id"); err != nil {
 		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/models/write", err)
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"testing"
 
+	openfga "github.com/openfga/go-sdk"
 	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 
 	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/fga"
 	mockclient "github.com/openfga/cli/internal/mocks"
+	"github.com/openfga/cli/internal/tuple"
 )
 
 var errMockWrite = errors.New("mock error")
@@ -97,3 +102,123 @@ func TestWriteModel(t *testing.T) {
 		t.Fatalf("Expected output %v actual %v", response, *output)
 	}
 }
+
+func TestCheckCompatibility(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	latestModel := authorizationmodel.AuthzModel{}
+	require.NoError(t, latestModel.ReadFromDSLString(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user, user:*]
+`))
+
+	mockReadModel := mockclient.NewMockSdkClientReadLatestAuthorizationModelRequestInterface(mockCtrl)
+	mockReadModel.EXPECT().Options(gomock.Any()).Return(mockReadModel)
+	mockReadModel.EXPECT().Execute().Return(&client.ClientReadAuthorizationModelResponse{
+		AuthorizationModel: &openfga.AuthorizationModel{
+			Id:              "01GXSA8YR785C4FYS3C0RTG7B1",
+			SchemaVersion:   latestModel.GetSchemaVersion(),
+			TypeDefinitions: latestModel.GetTypeDefinitions(),
+		},
+	}, nil)
+	mockFgaClient.EXPECT().ReadLatestAuthorizationModel(gomock.Any()).Return(mockReadModel)
+
+	mockReadRequest := mockclient.NewMockSdkClientReadRequestInterface(mockCtrl)
+	mockReadRequest.EXPECT().Body(client.ClientReadRequest{}).Return(mockReadRequest)
+	mockReadRequest.EXPECT().Options(client.ClientReadOptions{
+		PageSize:          openfga.PtrInt32(tuple.MaxReadPageSize),
+		ContinuationToken: openfga.PtrString(""),
+	}).Return(mockReadRequest)
+	mockReadRequest.EXPECT().Execute().Return(&client.ClientReadResponse{
+		Tuples: []openfga.Tuple{
+			{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+			{Key: openfga.TupleKey{User: "user:*", Relation: "viewer", Object: "document:1"}},
+		},
+	}, nil)
+	mockFgaClient.EXPECT().Read(gomock.Any()).Return(mockReadRequest)
+
+	newModel := authorizationmodel.AuthzModel{}
+	require.NoError(t, newModel.ReadFromDSLString(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`))
+
+	report, err := checkCompatibility(t.Context(), fga.ClientConfig{}, mockFgaClient, &newModel, true, 1)
+	require.NoError(t, err)
+
+	assert.False(t, report.Compatible)
+	assert.Equal(t, []authorizationmodel.BreakingChange{{
+		Type:                   "document",
+		Relation:               "viewer",
+		DirectlyAssignableType: "user:*",
+		Reason:                 "directly assignable type removed",
+	}}, report.BreakingChanges)
+	require.NotNil(t, report.Tuples)
+	assert.Equal(t, 2, report.Tuples.ScannedCount)
+	assert.Equal(t, 1, report.Tuples.InvalidCount)
+	assert.True(t, report.Tuples.Complete)
+	assert.Equal(t, "user:*", report.Tuples.InvalidTuples[0].Tuple.User)
+}
+
+func TestScanInvalidTuplesStopsAtMaxPages(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	pages := []struct {
+		continuationToken string
+		response          *client.ClientReadResponse
+	}{
+		{"", &client.ClientReadResponse{
+			Tuples:            []openfga.Tuple{{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}}},
+			ContinuationToken: "page-2",
+		}},
+		{"page-2", &client.ClientReadResponse{
+			Tuples:            []openfga.Tuple{{Key: openfga.TupleKey{User: "user:*", Relation: "viewer", Object: "document:1"}}},
+			ContinuationToken: "page-3",
+		}},
+	}
+
+	for _, page := range pages {
+		mockReadRequest := mockclient.NewMockSdkClientReadRequestInterface(mockCtrl)
+		mockReadRequest.EXPECT().Body(client.ClientReadRequest{}).Return(mockReadRequest)
+		mockReadRequest.EXPECT().Options(client.ClientReadOptions{
+			PageSize:          openfga.PtrInt32(tuple.MaxReadPageSize),
+			ContinuationToken: openfga.PtrString(page.continuationToken),
+		}).Return(mockReadRequest)
+		mockReadRequest.EXPECT().Execute().Return(page.response, nil)
+		mockFgaClient.EXPECT().Read(gomock.Any()).Return(mockReadRequest)
+	}
+
+	authModel := authorizationmodel.AuthzModel{}
+	require.NoError(t, authModel.ReadFromDSLString(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`))
+
+	scan, err := scanInvalidTuples(t.Context(), mockFgaClient, &authModel, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, scan.ScannedCount)
+	assert.Equal(t, 1, scan.InvalidCount)
+	assert.False(t, scan.Complete, "the third page was not read")
+}
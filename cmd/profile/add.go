@@ -0,0 +1,116 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/profile"
+)
+
+// addCmd represents the profile add command.
+var addCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a connection profile",
+	Long: "Add a connection profile to the CLI config file. Only the settings passed as flags " +
+		"are stored in the profile; settings from the environment or the config file are ignored.",
+	Example: `  fga profile add dev --api-url http://localhost:8080 --store-id 01H0H015178Y2V4CX10C2KGHF4
+  fga profile add prod --api-url https://api.fga.example --client-id ID --client-secret SECRET --api-token-issuer issuer.fga.example --api-audience https://api.fga.example/ --use`, //nolint:lll
+	Args: cobra.ExactArgs(1),
+	// The profile must only contain what was passed on the command line, so flags
+	// are not populated from the active config.
+	Annotations: map[string]string{cmdutils.SkipViperBindingAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := readConfigFile(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to add profile due to %w", err)
+		}
+
+		prof := profileFromFlags(cmd.Flags())
+
+		if err := configFile.Add(args[0], prof); err != nil {
+			return fmt.Errorf("failed to add profile due to %w", err)
+		}
+
+		if use, _ := cmd.Flags().GetBool("use"); use {
+			if err := configFile.Use(args[0]); err != nil {
+				return fmt.Errorf("failed to add profile due to %w", err)
+			}
+		}
+
+		if err := configFile.Save(); err != nil {
+			return fmt.Errorf("failed to add profile due to %w", err)
+		}
+
+		return output.Display(buildProfileOutput(args[0], prof, args[0] == configFile.CurrentProfile)) //nolint:wrapcheck
+	},
+}
+
+func profileFromFlags(flags *pflag.FlagSet) profile.Profile {
+	getString := func(name string) string {
+		if !flags.Changed(name) {
+			return ""
+		}
+
+		value, _ := flags.GetString(name)
+
+		return value
+	}
+
+	getStringArray := func(name string) []string {
+		if !flags.Changed(name) {
+			return nil
+		}
+
+		value, _ := flags.GetStringArray(name)
+
+		return value
+	}
+
+	return profile.Profile{
+		APIURL:         getString("api-url"),
+		StoreID:        getString("store-id"),
+		ModelID:        getString("model-id"),
+		APIToken:       getString("api-token"),
+		APITokenIssuer: getString("api-token-issuer"),
+		APIAudience:    getString("api-audience"),
+		APIScopes:      getStringArray("api-scopes"),
+		ClientID:       getString("client-id"),
+		ClientSecret:   getString("client-secret"),
+		CustomHeaders:  getStringArray("custom-headers"),
+	}
+}
+
+func init() {
+	// These shadow the global flags of the same name, which may already hold values from the active config.
+	addCmd.Flags().String("api-url", "", "OpenFGA API URI e.g. https://api.fga.example:8080")
+	addCmd.Flags().String("store-id", "", "Store ID")
+	addCmd.Flags().String("model-id", "", "Authorization Model ID")
+	addCmd.Flags().String("api-token", "", "API Token. Will be sent in as a Bearer in the Authorization header")
+	addCmd.Flags().String("api-token-issuer", "", "API Token Issuer. Used in the Client Credentials flow")
+	addCmd.Flags().String("api-audience", "", "API Audience. Used when performing the Client Credentials flow")
+	addCmd.Flags().String("client-id", "", "Client ID. Sent to the Token Issuer during the Client Credentials flow")
+	addCmd.Flags().String("client-secret", "", "Client Secret. Sent to the Token Issuer during the Client Credentials flow") //nolint:lll
+	addCmd.Flags().StringArray("api-scopes", []string{}, "API Scopes (repeat option for multiple values)")
+	addCmd.Flags().StringArray("custom-headers", []string{}, "Custom HTTP headers in 'Header: value' format (repeat option for multiple values)") //nolint:lll
+	addCmd.Flags().Bool("use", false, "Also make the new profile the current profile")
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/output"
+)
+
+// listCmd represents the profile list command.
+var listCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List connection profiles",
+	Long:    "List the connection profiles defined in the CLI config file.",
+	Example: "fga profile list",
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		configFile, err := readConfigFile(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to list profiles due to %w", err)
+		}
+
+		profiles := make([]profileOutput, 0, len(configFile.Profiles))
+		for _, name := range configFile.Names() {
+			profiles = append(profiles,
+				buildProfileOutput(name, configFile.Profiles[name], name == configFile.CurrentProfile))
+		}
+
+		return output.Display(map[string]any{ //nolint:wrapcheck
+			"current_profile": configFile.CurrentProfile,
+			"profiles":        profiles,
+		})
+	},
+}
@@ -0,0 +1,89 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profile contains commands to manage named connection profiles in the CLI config file.
+package profile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/profile"
+)
+
+const redactedValue = "********"
+
+// ProfileCmd represents the profile command.
+var ProfileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage connection profiles",
+	Long: "List, show, select, add and remove named connection profiles stored in the CLI config file. " +
+		"Select a profile for a single command with --profile or FGA_PROFILE, " +
+		"or persistently with \"fga profile use\".",
+}
+
+type profileOutput struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	fga.ClientConfig
+}
+
+func init() {
+	ProfileCmd.AddCommand(listCmd)
+	ProfileCmd.AddCommand(showCmd)
+	ProfileCmd.AddCommand(useCmd)
+	ProfileCmd.AddCommand(addCmd)
+	ProfileCmd.AddCommand(removeCmd)
+}
+
+// readConfigFile reads the config file in use, which is the one passed to --config or
+// found on startup, falling back to $HOME/.fga.yaml when none exists yet.
+func readConfigFile(cmd *cobra.Command) (*profile.ConfigFile, error) {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to find home directory due to %w", err)
+		}
+
+		path = filepath.Join(homeDir, ".fga.yaml")
+	}
+
+	return profile.ReadConfigFile(path) //nolint:wrapcheck
+}
+
+// buildProfileOutput returns the client configuration of a profile for display, with secrets redacted.
+func buildProfileOutput(name string, prof profile.Profile, current bool) profileOutput {
+	clientConfig := prof.ClientConfig()
+
+	if clientConfig.APIToken != "" {
+		clientConfig.APIToken = redactedValue
+	}
+
+	if clientConfig.ClientSecret != "" {
+		clientConfig.ClientSecret = redactedValue
+	}
+
+	return profileOutput{
+		Name:         name,
+		Current:      current,
+		ClientConfig: clientConfig,
+	}
+}
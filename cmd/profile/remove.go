@@ -0,0 +1,51 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/output"
+)
+
+// removeCmd represents the profile remove command.
+var removeCmd = &cobra.Command{
+	Use:     "remove <name>",
+	Aliases: []string{"rm"},
+	Short:   "Remove a connection profile",
+	Long:    "Remove a connection profile from the CLI config file.",
+	Example: "fga profile remove staging",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := readConfigFile(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to remove profile due to %w", err)
+		}
+
+		if err := configFile.Remove(args[0]); err != nil {
+			return fmt.Errorf("failed to remove profile due to %w", err)
+		}
+
+		if err := configFile.Save(); err != nil {
+			return fmt.Errorf("failed to remove profile due to %w", err)
+		}
+
+		return output.Display(output.EmptyStruct{}) //nolint:wrapcheck
+	},
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/output"
+)
+
+var errNoCurrentProfile = errors.New("no profile name given and no current profile is set")
+
+// showCmd represents the profile show command.
+var showCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show a connection profile",
+	Long: "Show the client configuration built from a connection profile, with secrets redacted. " +
+		"Shows the current profile if no name is given.",
+	Example: "fga profile show staging",
+	Args:    cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := readConfigFile(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to show profile due to %w", err)
+		}
+
+		name := configFile.CurrentProfile
+		if len(args) > 0 {
+			name = args[0]
+		}
+
+		if name == "" {
+			return errNoCurrentProfile
+		}
+
+		prof, err := configFile.Get(name)
+		if err != nil {
+			return fmt.Errorf("failed to show profile due to %w", err)
+		}
+
+		return output.Display(buildProfileOutput(name, prof, name == configFile.CurrentProfile)) //nolint:wrapcheck
+	},
+}
@@ -0,0 +1,50 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package profile
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/output"
+)
+
+// useCmd represents the profile use command.
+var useCmd = &cobra.Command{
+	Use:     "use <name>",
+	Short:   "Set the current connection profile",
+	Long:    "Set the profile applied to every command that is not given --profile or FGA_PROFILE.",
+	Example: "fga profile use staging",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		configFile, err := readConfigFile(cmd)
+		if err != nil {
+			return fmt.Errorf("failed to use profile due to %w", err)
+		}
+
+		if err := configFile.Use(args[0]); err != nil {
+			return fmt.Errorf("failed to use profile due to %w", err)
+		}
+
+		if err := configFile.Save(); err != nil {
+			return fmt.Errorf("failed to use profile due to %w", err)
+		}
+
+		return output.Display(map[string]string{"current_profile": args[0]}) //nolint:wrapcheck
+	},
+}
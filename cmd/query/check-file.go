@@ -0,0 +1,444 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/requests"
+	"github.com/openfga/cli/internal/tuple"
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+// defaultMaxChecksPerBatch matches the default maximum batch size of the OpenFGA server.
+const defaultMaxChecksPerBatch = 50
+
+var checkResultsCSVHeaders = []string{"user", "relation", "object", "allowed", "error", "latency_ms"}
+
+// checkFileResult is the outcome of the check of a row of the checks file.
+type checkFileResult struct {
+	User      string `json:"user"`
+	Relation  string `json:"relation"`
+	Object    string `json:"object"`
+	Allowed   *bool  `json:"allowed,omitempty"`
+	Error     string `json:"error,omitempty"`
+	LatencyMs int64  `json:"latency_ms"`
+}
+
+type checkFileOptions struct {
+	maxChecksPerBatch   int
+	maxParallelRequests int
+	maxRPS              int
+	rampUpPeriodInSec   int
+	contextualTuples    []client.ClientContextualTupleKey
+	queryContext        *map[string]any
+	consistency         *openfga.ConsistencyPreference
+}
+
+// checkFileRunner checks the rows of a checks file in batches. Each batch is sent with BatchCheck,
+// unless the server does not support it, in which case its rows are checked one by one.
+type checkFileRunner struct {
+	fgaClient        client.SdkClient
+	options          checkFileOptions
+	checks           []client.ClientBatchCheckItem
+	results          []checkFileResult
+	batchUnsupported atomic.Bool
+}
+
+// readChecksFile reads the checks from a file in any of the tuple file formats. The condition
+// context of a row, if any, is sent as the context of its check.
+func readChecksFile(fileName string) ([]client.ClientBatchCheckItem, error) {
+	tuples, err := tuplefile.ReadTupleFile(fileName)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	checks := make([]client.ClientBatchCheckItem, 0, len(tuples))
+
+	for index, tupleKey := range tuples {
+		check := client.ClientBatchCheckItem{
+			User:          tupleKey.User,
+			Relation:      tupleKey.Relation,
+			Object:        tupleKey.Object,
+			CorrelationId: strconv.Itoa(index),
+		}
+
+		if tupleKey.Condition != nil && tupleKey.Condition.Context != nil {
+			check.Context = tupleKey.Condition.Context
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+func checkFile(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	checks []client.ClientBatchCheckItem,
+	options checkFileOptions,
+) ([]checkFileResult, error) {
+	runner := &checkFileRunner{
+		fgaClient: fgaClient,
+		options:   options,
+		checks:    checks,
+		results:   make([]checkFileResult, len(checks)),
+	}
+
+	for index, check := range checks {
+		if len(options.contextualTuples) > 0 {
+			check.ContextualTuples = append(check.ContextualTuples, options.contextualTuples...)
+		}
+
+		if check.Context == nil {
+			check.Context = options.queryContext
+		}
+
+		runner.checks[index] = check
+		runner.results[index] = checkFileResult{User: check.User, Relation: check.Relation, Object: check.Object}
+	}
+
+	numBatches := (len(checks) + options.maxChecksPerBatch - 1) / options.maxChecksPerBatch
+	reqs := make([]func() error, 0, numBatches)
+
+	for batchIndex := range numBatches {
+		start := batchIndex * options.maxChecksPerBatch
+		end := min(start+options.maxChecksPerBatch, len(checks))
+
+		reqs = append(reqs, func() error {
+			runner.checkBatch(ctx, start, end)
+
+			return nil
+		})
+	}
+
+	if options.maxRPS > 0 {
+		if err := requests.RampUpAPIRequests(
+			ctx, tuple.DefaultMinRPS, options.maxRPS, options.rampUpPeriodInSec, time.Second,
+			options.maxParallelRequests, reqs,
+		); err != nil {
+			return nil, fmt.Errorf("failed to run checks due to %w", err)
+		}
+
+		return runner.results, nil
+	}
+
+	_ = requests.RunInParallel(len(reqs), options.maxParallelRequests, func(index int) error {
+		return reqs[index]()
+	})
+
+	return runner.results, nil
+}
+
+func (runner *checkFileRunner) checkBatch(ctx context.Context, start int, end int) {
+	if !runner.batchUnsupported.Load() {
+		startTime := time.Now()
+
+		response, err := runner.batchCheck(ctx, runner.checks[start:end])
+		if err == nil {
+			latency := time.Since(startTime).Milliseconds()
+			results := response.GetResult()
+
+			for index := start; index < end; index++ {
+				result, found := results[runner.checks[index].CorrelationId]
+
+				switch {
+				case !found:
+					runner.results[index].Error = "no result returned"
+				case result.Error != nil:
+					runner.results[index].Error = result.Error.GetMessage()
+				default:
+					runner.results[index].Allowed = openfga.PtrBool(result.GetAllowed())
+				}
+
+				runner.results[index].LatencyMs = latency
+			}
+
+			return
+		}
+
+		if !isBatchCheckUnsupported(err) {
+			for index := start; index < end; index++ {
+				runner.results[index].Error = err.Error()
+			}
+
+			return
+		}
+
+		runner.batchUnsupported.Store(true)
+	}
+
+	for index := start; index < end; index++ {
+		runner.checkSingle(ctx, index)
+	}
+}
+
+func (runner *checkFileRunner) batchCheck(
+	ctx context.Context,
+	checks []client.ClientBatchCheckItem,
+) (*openfga.BatchCheckResponse, error) {
+	options := client.BatchCheckOptions{
+		MaxBatchSize:        openfga.PtrInt32(int32(len(checks))), //nolint:gosec
+		MaxParallelRequests: openfga.PtrInt32(1),
+	}
+
+	if *runner.options.consistency != openfga.CONSISTENCYPREFERENCE_UNSPECIFIED {
+		options.Consistency = runner.options.consistency
+	}
+
+	response, err := runner.fgaClient.BatchCheck(ctx).Body(client.ClientBatchCheckRequest{Checks: checks}).
+		Options(options).Execute()
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return response, nil
+}
+
+func (runner *checkFileRunner) checkSingle(ctx context.Context, index int) {
+	item := runner.checks[index]
+	startTime := time.Now()
+
+	response, err := check(
+		ctx, runner.fgaClient, item.User, item.Relation, item.Object, item.ContextualTuples, item.Context,
+		runner.options.consistency,
+	)
+
+	runner.results[index].LatencyMs = time.Since(startTime).Milliseconds()
+
+	if err != nil {
+		runner.results[index].Error = err.Error()
+
+		return
+	}
+
+	runner.results[index].Allowed = openfga.PtrBool(response.GetAllowed())
+}
+
+// isBatchCheckUnsupported returns whether the error is that of a server without the BatchCheck
+// endpoint, which was added in OpenFGA v1.8.0.
+func isBatchCheckUnsupported(err error) bool {
+	var statusErr interface{ ResponseStatusCode() int }
+
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	return statusErr.ResponseStatusCode() == http.StatusNotFound ||
+		statusErr.ResponseStatusCode() == http.StatusNotImplemented
+}
+
+func validateResultsFileFormat(fileName string) error {
+	switch path.Ext(fileName) {
+	case "", ".csv", ".jsonl":
+		return nil
+	default:
+		return fmt.Errorf("unsupported results file format %q, use .csv or .jsonl", path.Ext(fileName)) //nolint:err113
+	}
+}
+
+// writeCheckResults writes the results in the format of the results file name, csv or jsonl.
+func writeCheckResults(fileName string, writer io.Writer, results []checkFileResult) error {
+	if path.Ext(fileName) == ".jsonl" {
+		encoder := json.NewEncoder(writer)
+
+		for _, result := range results {
+			if err := encoder.Encode(result); err != nil {
+				return fmt.Errorf("failed to write check result: %w", err)
+			}
+		}
+
+		return nil
+	}
+
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write(checkResultsCSVHeaders); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, result := range results {
+		allowed := ""
+		if result.Allowed != nil {
+			allowed = strconv.FormatBool(*result.Allowed)
+		}
+
+		record := []string{
+			result.User, result.Relation, result.Object, allowed, result.Error,
+			strconv.FormatInt(result.LatencyMs, 10),
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write check result: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write check results: %w", err)
+	}
+
+	return nil
+}
+
+func writeCheckResultsFile(fileName string, results []checkFileResult) error {
+	file, err := os.Create(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to create results file due to %w", err)
+	}
+	defer file.Close()
+
+	if err := writeCheckResults(fileName, file, results); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write results file due to %w", err)
+	}
+
+	return nil
+}
+
+func getCheckFileOptions(cmd *cobra.Command) (*checkFileOptions, error) {
+	options := &checkFileOptions{}
+	flags := cmd.Flags()
+
+	options.maxChecksPerBatch, _ = flags.GetInt("max-checks-per-batch")
+	options.maxParallelRequests, _ = flags.GetInt("max-parallel-requests")
+	options.maxRPS, _ = flags.GetInt("max-rps")
+	options.rampUpPeriodInSec, _ = flags.GetInt("rampup-period-in-sec")
+
+	if options.maxChecksPerBatch <= 0 {
+		return nil, errors.New("max-checks-per-batch must be greater than zero") //nolint:err113
+	}
+
+	if options.maxParallelRequests <= 0 {
+		return nil, errors.New("max-parallel-requests must be greater than zero") //nolint:err113
+	}
+
+	if flags.Changed("max-rps") && options.maxRPS <= 0 {
+		return nil, errors.New("max-rps must be greater than zero") //nolint:err113
+	}
+
+	if flags.Changed("rampup-period-in-sec") && options.rampUpPeriodInSec <= 0 {
+		return nil, errors.New("rampup-period-in-sec must be greater than zero") //nolint:err113
+	}
+
+	if options.maxRPS > 0 && !flags.Changed("rampup-period-in-sec") {
+		options.rampUpPeriodInSec = options.maxRPS * tuple.RPSToRampupPeriodMultiplier
+	}
+
+	var err error
+
+	if options.contextualTuples, err = cmdutils.ParseContextualTuples(cmd); err != nil {
+		return nil, fmt.Errorf("error parsing contextual tuples for check: %w", err)
+	}
+
+	if options.queryContext, err = cmdutils.ParseQueryContext(cmd, "context"); err != nil {
+		return nil, fmt.Errorf("error parsing query context for check: %w", err)
+	}
+
+	if options.consistency, err = cmdutils.ParseConsistencyFromCmd(cmd); err != nil {
+		return nil, fmt.Errorf("error parsing consistency for check: %w", err)
+	}
+
+	return options, nil
+}
+
+// checkFileResponse is the output of a check of a file. The results are only included when they
+// are not written to a results file.
+type checkFileResponse struct {
+	Results      []checkFileResult `json:"results,omitempty"`
+	ResultsFile  string            `json:"results_file,omitempty"`
+	TotalCount   int               `json:"total_count"`
+	AllowedCount int               `json:"allowed_count"`
+	DeniedCount  int               `json:"denied_count"`
+	ErrorCount   int               `json:"error_count"`
+	TimeSpent    string            `json:"time_spent"`
+}
+
+func checkFromFile(cmd *cobra.Command, fgaClient client.SdkClient) error {
+	startTime := time.Now()
+
+	fileName, _ := cmd.Flags().GetString("file")
+	resultsFile, _ := cmd.Flags().GetString("results-file")
+
+	if err := validateResultsFileFormat(resultsFile); err != nil {
+		return err
+	}
+
+	options, err := getCheckFileOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	checks, err := readChecksFile(fileName)
+	if err != nil {
+		return err
+	}
+
+	results, err := checkFile(cmd.Context(), fgaClient, checks, *options)
+	if err != nil {
+		return err
+	}
+
+	response := checkFileResponse{TotalCount: len(results)}
+
+	for _, result := range results {
+		switch {
+		case result.Allowed == nil:
+			response.ErrorCount++
+		case *result.Allowed:
+			response.AllowedCount++
+		default:
+			response.DeniedCount++
+		}
+	}
+
+	if resultsFile != "" {
+		if err := writeCheckResultsFile(resultsFile, results); err != nil {
+			return err
+		}
+
+		response.ResultsFile = resultsFile
+	} else {
+		response.Results = results
+	}
+
+	response.TimeSpent = time.Since(startTime).String()
+
+	return output.Display(response) //nolint:wrapcheck
+}
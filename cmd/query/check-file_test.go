@@ -0,0 +1,74 @@
+package query
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const checksFile = `user_type,user_id,user_relation,relation,object_type,object_id,condition_name,condition_context
+user,anne,,viewer,document,roadmap,,
+user,beth,,viewer,document,roadmap,,
+user,anne,,editor,document,roadmap,,
+`
+
+func TestCheckFile(t *testing.T) {
+	t.Parallel()
+
+	checksFileName := filepath.Join(t.TempDir(), "checks.csv")
+	require.NoError(t, os.WriteFile(checksFileName, []byte(checksFile), 0o600))
+
+	_, fgaClient := newLocalStoreClient(t, localStoreFile)
+
+	checks, err := readChecksFile(checksFileName)
+	require.NoError(t, err)
+	require.Len(t, checks, 3)
+
+	for _, maxRPS := range []int{0, 10} {
+		results, err := checkFile(t.Context(), fgaClient, checks, checkFileOptions{
+			maxChecksPerBatch:   2,
+			maxParallelRequests: 2,
+			maxRPS:              maxRPS,
+			consistency:         openfga.CONSISTENCYPREFERENCE_UNSPECIFIED.Ptr(),
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+
+		assert.Equal(t, "user:anne", results[0].User)
+		assert.Equal(t, openfga.PtrBool(true), results[0].Allowed)
+		assert.Equal(t, openfga.PtrBool(false), results[1].Allowed)
+		assert.Nil(t, results[2].Allowed)
+		assert.Contains(t, results[2].Error, "editor")
+	}
+}
+
+func TestWriteCheckResults(t *testing.T) {
+	t.Parallel()
+
+	results := []checkFileResult{
+		{User: "user:anne", Relation: "viewer", Object: "document:roadmap", Allowed: openfga.PtrBool(true), LatencyMs: 3},
+		{User: "user:anne", Relation: "editor", Object: "document:roadmap", Error: "invalid relation", LatencyMs: 1},
+	}
+
+	var csvOutput bytes.Buffer
+
+	require.NoError(t, writeCheckResults("results.csv", &csvOutput, results))
+	assert.Equal(t, `user,relation,object,allowed,error,latency_ms
+user:anne,viewer,document:roadmap,true,,3
+user:anne,editor,document:roadmap,,invalid relation,1
+`, csvOutput.String())
+
+	var jsonlOutput bytes.Buffer
+
+	require.NoError(t, writeCheckResults("results.jsonl", &jsonlOutput, results))
+	assert.Equal(t, `{"user":"user:anne","relation":"viewer","object":"document:roadmap","allowed":true,"latency_ms":3}
+{"user":"user:anne","relation":"editor","object":"document:roadmap","error":"invalid relation","latency_ms":1}
+`, jsonlOutput.String())
+
+	require.Error(t, validateResultsFileFormat("results.yaml"))
+}
@@ -26,6 +26,8 @@ import (
 
 	"github.com/openfga/cli/internal/cmdutils"
 	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
+	"github.com/openfga/cli/internal/tuple"
 )
 
 func check(
@@ -62,19 +64,37 @@ func check(
 
 // checkCmd represents the check command.
 var checkCmd = &cobra.Command{
-	Use:     "check",
-	Short:   "Check",
-	Example: `fga query check --store-id="01H4P8Z95KTXXEP6Z03T75Q984" user:anne can_view document:roadmap --context '{"ip_address":"127.0.0.1"}' --consistency "HIGHER_CONSISTENCY"`, //nolint:lll
-	Long:    "Check if a user has a particular relation with an object.",
-	Args:    cobra.ExactArgs(3), //nolint:mnd
+	Use:   "check",
+	Short: "Check",
+	Example: `fga query check --store-id="01H4P8Z95KTXXEP6Z03T75Q984" user:anne can_view document:roadmap --context '{"ip_address":"127.0.0.1"}' --consistency "HIGHER_CONSISTENCY"
+fga query check --store-id="01H4P8Z95KTXXEP6Z03T75Q984" --file checks.csv --results-file results.csv --max-rps 50
+fga query check --store-id="01H4P8Z95KTXXEP6Z03T75Q984" user:anne can_view document:roadmap --record tests.fga.yaml`, //nolint:lll
+	Long: `Check if a user has a particular relation with an object.
+
+With --file, the checks are read from a file in any of the formats of "fga tuple write --file", one check per tuple. They are sent with BatchCheck, or one by one if the server does not support it. The result of each row, with its error and latency, is written to --results-file (.csv or .jsonl), or included in the output.`, //nolint:lll
+	Args: func(cmd *cobra.Command, args []string) error {
+		if cmd.Flags().Changed("file") {
+			return cobra.NoArgs(cmd, args)
+		}
+
+		return cobra.ExactArgs(3)(cmd, args) //nolint:mnd
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clientConfig := cmdutils.GetClientConfig(cmd)
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
 
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
 			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
 		}
 
+		if cmd.Flags().Changed("file") {
+			return checkFromFile(cmd, fgaClient)
+		}
+
 		contextualTuples, err := cmdutils.ParseContextualTuples(cmd)
 		if err != nil {
 			return fmt.Errorf("error parsing contextual tuples for check: %w", err)
@@ -97,8 +117,30 @@ var checkCmd = &cobra.Command{
 			return fmt.Errorf("check failed: %w", err)
 		}
 
-		return output.Display(*response)
+		if err := output.Display(*response); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return recordResult(cmd, func(fileName string, testName string) (string, error) {
+			return storetest.RecordCheck(fileName, testName, client.ClientCheckRequest{
+				User:             args[0],
+				Relation:         args[1],
+				Object:           args[2],
+				ContextualTuples: contextualTuples,
+				Context:          queryContext,
+			}, response.GetAllowed())
+		})
 	},
 }
 
-func init() {}
+func init() {
+	checkCmd.Flags().String("file", "", "File of checks to run, in any of the tuple file formats")
+	checkCmd.Flags().String("results-file", "", "File to write the result of each check to (.csv or .jsonl)")
+	checkCmd.Flags().Int("max-checks-per-batch", defaultMaxChecksPerBatch, "Max checks per BatchCheck request.")
+	checkCmd.Flags().Int("max-parallel-requests", tuple.MaxParallelRequests, "Max number of requests to issue to the server in parallel.") //nolint:lll
+	checkCmd.Flags().Int("max-rps", 0, "The maximum requests per second.")
+	checkCmd.Flags().Int("rampup-period-in-sec", 0, "The period over which to ramp up the request rate.")
+	addRecordFlags(checkCmd)
+
+	checkCmd.MarkFlagsMutuallyExclusive("file", "record")
+}
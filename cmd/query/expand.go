@@ -62,7 +62,11 @@ var expandCmd = &cobra.Command{
 	Example: `fga query expand --store-id="01H4P8Z95KTXXEP6Z03T75Q984" can_view document:roadmap --consistency "HIGHER_CONSISTENCY"`, //nolint:lll
 	Args:    cobra.ExactArgs(2),                                                                                                      //nolint:mnd,lll
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clientConfig := cmdutils.GetClientConfig(cmd)
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
 
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
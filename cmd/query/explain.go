@@ -0,0 +1,392 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/output"
+)
+
+// maxExplainDepth matches the default resolve depth of the OpenFGA server.
+const maxExplainDepth = 25
+
+const (
+	explainRuleDirect          = "direct"
+	explainRuleComputedUserset = "computed_userset"
+	explainRuleTupleToUserset  = "tuple_to_userset"
+	explainRuleUnion           = "union"
+	explainRuleIntersection    = "intersection"
+	explainRuleDifference      = "difference"
+)
+
+// explainStep is a step of the evaluation of a userset. When the user is allowed, only the
+// branches that grant access are kept, otherwise all the branches that were tried.
+type explainStep struct {
+	Userset  string         `json:"userset"`
+	Rule     string         `json:"rule,omitempty"`
+	Tuple    string         `json:"tuple,omitempty"`
+	Allowed  bool           `json:"allowed"`
+	Reason   string         `json:"reason,omitempty"`
+	Branches []*explainStep `json:"branches,omitempty"`
+}
+
+type explainResponse struct {
+	Allowed bool         `json:"allowed"`
+	Path    *explainStep `json:"path,omitempty"`
+	Tried   *explainStep `json:"tried,omitempty"`
+	Note    string       `json:"note,omitempty"`
+}
+
+// explainer walks the rewrite rules of the model with Expand calls, following the usersets and
+// tuple to userset relations that could lead to the user.
+type explainer struct {
+	fgaClient        client.SdkClient
+	user             string
+	contextualTuples []client.ClientContextualTupleKey
+	consistency      *openfga.ConsistencyPreference
+	// visiting holds the usersets being evaluated, to stop at cycles
+	visiting map[string]bool
+}
+
+func (e *explainer) expand(ctx context.Context, relation string, object string) (*openfga.Node, error) {
+	options := client.ClientExpandOptions{}
+	if *e.consistency != openfga.CONSISTENCYPREFERENCE_UNSPECIFIED {
+		options.Consistency = e.consistency
+	}
+
+	response, err := e.fgaClient.Expand(ctx).Body(client.ClientExpandRequest{
+		Relation:         relation,
+		Object:           object,
+		ContextualTuples: e.contextualTuples,
+	}).Options(options).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand %s#%s due to %w", object, relation, err)
+	}
+
+	if response.Tree == nil || response.Tree.Root == nil {
+		return &openfga.Node{Name: object + "#" + relation}, nil
+	}
+
+	return response.Tree.Root, nil
+}
+
+func (e *explainer) explainUserset(ctx context.Context, userset string, depth int) (*explainStep, error) {
+	if e.visiting[userset] {
+		return &explainStep{Userset: userset, Reason: "cycle, the userset is already being evaluated"}, nil
+	}
+
+	if depth > maxExplainDepth {
+		return &explainStep{Userset: userset, Reason: "maximum depth reached"}, nil
+	}
+
+	object, relation, found := strings.Cut(userset, "#")
+	if !found {
+		return nil, fmt.Errorf("invalid userset %s", userset) //nolint:err113
+	}
+
+	node, err := e.expand(ctx, relation, object)
+	if err != nil {
+		return nil, err
+	}
+
+	e.visiting[userset] = true
+	defer delete(e.visiting, userset)
+
+	return e.explainNode(ctx, node, depth)
+}
+
+func (e *explainer) explainNode(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	switch {
+	case node.Union != nil:
+		return e.explainUnion(ctx, node, depth)
+	case node.Intersection != nil:
+		return e.explainIntersection(ctx, node, depth)
+	case node.Difference != nil:
+		return e.explainDifference(ctx, node, depth)
+	case node.Leaf != nil && node.Leaf.Users != nil:
+		return e.explainUsers(ctx, node, depth)
+	case node.Leaf != nil && node.Leaf.Computed != nil:
+		branch, err := e.explainUserset(ctx, node.Leaf.Computed.Userset, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		return &explainStep{
+			Userset:  node.Name,
+			Rule:     explainRuleComputedUserset,
+			Allowed:  branch.Allowed,
+			Branches: []*explainStep{branch},
+		}, nil
+	case node.Leaf != nil && node.Leaf.TupleToUserset != nil:
+		return e.explainTupleToUserset(ctx, node, depth)
+	default:
+		return &explainStep{Userset: node.Name, Reason: "no relationship"}, nil
+	}
+}
+
+func (e *explainer) explainUnion(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	step := &explainStep{Userset: node.Name, Rule: explainRuleUnion}
+
+	for index := range node.Union.Nodes {
+		branch, err := e.explainNode(ctx, &node.Union.Nodes[index], depth)
+		if err != nil {
+			return nil, err
+		}
+
+		if branch.Allowed {
+			step.Allowed = true
+			step.Branches = []*explainStep{branch}
+
+			return step, nil
+		}
+
+		step.Branches = append(step.Branches, branch)
+	}
+
+	step.Reason = "no branch grants access"
+
+	return step, nil
+}
+
+func (e *explainer) explainIntersection(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	step := &explainStep{Userset: node.Name, Rule: explainRuleIntersection, Allowed: true}
+
+	for index := range node.Intersection.Nodes {
+		branch, err := e.explainNode(ctx, &node.Intersection.Nodes[index], depth)
+		if err != nil {
+			return nil, err
+		}
+
+		if !branch.Allowed {
+			step.Allowed = false
+			step.Reason = "not every branch grants access"
+		}
+
+		step.Branches = append(step.Branches, branch)
+	}
+
+	return step, nil
+}
+
+func (e *explainer) explainDifference(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	step := &explainStep{Userset: node.Name, Rule: explainRuleDifference}
+
+	base, err := e.explainNode(ctx, &node.Difference.Base, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	step.Branches = []*explainStep{base}
+
+	if !base.Allowed {
+		step.Reason = "the base does not grant access"
+
+		return step, nil
+	}
+
+	subtract, err := e.explainNode(ctx, &node.Difference.Subtract, depth)
+	if err != nil {
+		return nil, err
+	}
+
+	step.Branches = append(step.Branches, subtract)
+	step.Allowed = !subtract.Allowed
+
+	if subtract.Allowed {
+		step.Reason = "access is excluded by the subtracted branch"
+	}
+
+	return step, nil
+}
+
+// explainUsers looks for the user in the users directly related to the object: the user itself,
+// a type wildcard, or a userset the user is a member of.
+func (e *explainer) explainUsers(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	object, relation, _ := strings.Cut(node.Name, "#")
+	step := &explainStep{Userset: node.Name, Rule: explainRuleDirect}
+
+	userType, _, _ := strings.Cut(e.user, ":")
+	usersets := []string{}
+
+	for _, user := range node.Leaf.Users.Users {
+		if user == e.user || (user == userType+":*" && !strings.Contains(e.user, "#")) {
+			step.Allowed = true
+			step.Tuple = fmt.Sprintf("%s %s %s", user, relation, object)
+
+			return step, nil
+		}
+
+		if strings.Contains(user, "#") {
+			usersets = append(usersets, user)
+		}
+	}
+
+	for _, userset := range usersets {
+		branch, err := e.explainUserset(ctx, userset, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		tupleStep := &explainStep{
+			Userset:  node.Name,
+			Rule:     explainRuleDirect,
+			Tuple:    fmt.Sprintf("%s %s %s", userset, relation, object),
+			Allowed:  branch.Allowed,
+			Branches: []*explainStep{branch},
+		}
+
+		if branch.Allowed {
+			return tupleStep, nil
+		}
+
+		step.Branches = append(step.Branches, tupleStep)
+	}
+
+	step.Reason = "no tuple relates the user directly"
+
+	return step, nil
+}
+
+func (e *explainer) explainTupleToUserset(ctx context.Context, node *openfga.Node, depth int) (*explainStep, error) {
+	object, tuplesetRelation, _ := strings.Cut(node.Leaf.TupleToUserset.Tupleset, "#")
+	step := &explainStep{Userset: node.Name, Rule: explainRuleTupleToUserset}
+
+	for _, computed := range node.Leaf.TupleToUserset.Computed {
+		parent, _, _ := strings.Cut(computed.Userset, "#")
+
+		branch, err := e.explainUserset(ctx, computed.Userset, depth+1)
+		if err != nil {
+			return nil, err
+		}
+
+		tupleStep := &explainStep{
+			Userset:  node.Name,
+			Rule:     explainRuleTupleToUserset,
+			Tuple:    fmt.Sprintf("%s %s %s", parent, tuplesetRelation, object),
+			Allowed:  branch.Allowed,
+			Branches: []*explainStep{branch},
+		}
+
+		if branch.Allowed {
+			return tupleStep, nil
+		}
+
+		step.Branches = append(step.Branches, tupleStep)
+	}
+
+	step.Reason = fmt.Sprintf("no object related through %s grants access", node.Leaf.TupleToUserset.Tupleset)
+
+	return step, nil
+}
+
+func explain(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	user string,
+	relation string,
+	object string,
+	contextualTuples []client.ClientContextualTupleKey,
+	queryContext *map[string]any,
+	consistency *openfga.ConsistencyPreference,
+) (*explainResponse, error) {
+	checkResponse, err := check(ctx, fgaClient, user, relation, object, contextualTuples, queryContext, consistency)
+	if err != nil {
+		return nil, fmt.Errorf("check failed: %w", err)
+	}
+
+	walker := &explainer{
+		fgaClient:        fgaClient,
+		user:             user,
+		contextualTuples: contextualTuples,
+		consistency:      consistency,
+		visiting:         map[string]bool{},
+	}
+
+	step, err := walker.explainUserset(ctx, object+"#"+relation, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &explainResponse{Allowed: checkResponse.GetAllowed()}
+	if step.Allowed {
+		response.Path = step
+	} else {
+		response.Tried = step
+	}
+
+	if step.Allowed != response.Allowed {
+		response.Note = "the check result differs from the expanded relationships, " +
+			"as conditions are evaluated by check but not by expand"
+	}
+
+	return response, nil
+}
+
+// explainCmd represents the explain command.
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "Explain a check",
+	Long: `Explain why a user has, or does not have, a particular relation with an object.
+
+The rewrite rules of the model are walked with Expand calls. When the user is allowed, the path of tuples and usersets that grants access is returned, otherwise the branches that were tried and failed.`, //nolint:lll
+	Example: `fga query explain --store-id="01H4P8Z95KTXXEP6Z03T75Q984" user:anne can_view document:roadmap`,
+	Args:    cobra.ExactArgs(3), //nolint:mnd
+	RunE: func(cmd *cobra.Command, args []string) error {
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
+
+		fgaClient, err := clientConfig.GetFgaClient()
+		if err != nil {
+			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+		}
+
+		contextualTuples, err := cmdutils.ParseContextualTuples(cmd)
+		if err != nil {
+			return fmt.Errorf("error parsing contextual tuples for explain: %w", err)
+		}
+
+		queryContext, err := cmdutils.ParseQueryContext(cmd, "context")
+		if err != nil {
+			return fmt.Errorf("error parsing query context for explain: %w", err)
+		}
+
+		consistency, err := cmdutils.ParseConsistencyFromCmd(cmd)
+		if err != nil {
+			return fmt.Errorf("error parsing consistency for explain: %w", err)
+		}
+
+		response, err := explain(
+			cmd.Context(), fgaClient, args[0], args[1], args[2], contextualTuples, queryContext, consistency,
+		)
+		if err != nil {
+			return err
+		}
+
+		return output.Display(*response) //nolint:wrapcheck
+	},
+}
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const explainStoreFile = `model: |
+  model
+    schema 1.1
+  type user
+  type group
+    relations
+      define member: [user, group#member]
+  type folder
+    relations
+      define viewer: [user, group#member]
+  type document
+    relations
+      define parent: [folder]
+      define owner: [user]
+      define blocked: [user]
+      define editor: [user:*]
+      define viewer: [user, group#member] or owner or viewer from parent
+      define can_edit: (editor and viewer) but not blocked
+tuples:
+  - user: user:anne
+    relation: owner
+    object: document:roadmap
+  - user: group:eng#member
+    relation: member
+    object: group:all
+  - user: group:all#member
+    relation: member
+    object: group:eng
+  - user: user:beth
+    relation: member
+    object: group:eng
+  - user: group:all#member
+    relation: viewer
+    object: folder:product
+  - user: folder:product
+    relation: parent
+    object: document:roadmap
+  - user: user:*
+    relation: editor
+    object: document:roadmap
+  - user: user:beth
+    relation: blocked
+    object: document:roadmap
+`
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	_, fgaClient := newLocalStoreClient(t, explainStoreFile)
+
+	consistency := openfga.CONSISTENCYPREFERENCE_UNSPECIFIED.Ptr()
+
+	t.Run("computed userset", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := explain(t.Context(), fgaClient, "user:anne", "viewer", "document:roadmap", nil, nil, consistency)
+		require.NoError(t, err)
+		assert.True(t, response.Allowed)
+		assert.Nil(t, response.Tried)
+		require.NotNil(t, response.Path)
+		assert.Equal(t, []string{"document:roadmap#owner"}, explainedUsersets(response.Path))
+		assert.Equal(t, "user:anne owner document:roadmap", lastTuple(response.Path))
+	})
+
+	t.Run("tuple to userset through a cycle", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := explain(t.Context(), fgaClient, "user:beth", "viewer", "document:roadmap", nil, nil, consistency)
+		require.NoError(t, err)
+		assert.True(t, response.Allowed)
+		require.NotNil(t, response.Path)
+		assert.Equal(t, []string{"folder:product#viewer", "group:all#member", "group:eng#member"},
+			explainedUsersets(response.Path))
+		assert.Equal(t, "user:beth member group:eng", lastTuple(response.Path))
+	})
+
+	t.Run("difference", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := explain(t.Context(), fgaClient, "user:beth", "can_edit", "document:roadmap", nil, nil, consistency)
+		require.NoError(t, err)
+		assert.False(t, response.Allowed)
+		assert.Nil(t, response.Path)
+		require.NotNil(t, response.Tried)
+		assert.Equal(t, explainRuleDifference, response.Tried.Rule)
+		assert.Equal(t, "access is excluded by the subtracted branch", response.Tried.Reason)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		t.Parallel()
+
+		response, err := explain(t.Context(), fgaClient, "user:carl", "viewer", "document:roadmap", nil, nil, consistency)
+		require.NoError(t, err)
+		assert.False(t, response.Allowed)
+		assert.Empty(t, response.Note)
+		require.NotNil(t, response.Tried)
+		assert.Equal(t, explainRuleUnion, response.Tried.Rule)
+		assert.Len(t, response.Tried.Branches, 3)
+	})
+}
+
+// explainedUsersets returns the usersets walked into along the first branches, besides the root.
+func explainedUsersets(step *explainStep) []string {
+	usersets := []string{}
+	root := step.Userset
+
+	for step != nil {
+		if step.Userset != root && (len(usersets) == 0 || usersets[len(usersets)-1] != step.Userset) {
+			usersets = append(usersets, step.Userset)
+		}
+
+		if len(step.Branches) == 0 {
+			break
+		}
+
+		step = step.Branches[0]
+	}
+
+	return usersets
+}
+
+func lastTuple(step *explainStep) string {
+	tuple := ""
+
+	for step != nil {
+		if step.Tuple != "" {
+			tuple = step.Tuple
+		}
+
+		if len(step.Branches) == 0 {
+			break
+		}
+
+		step = step.Branches[0]
+	}
+
+	return tuple
+}
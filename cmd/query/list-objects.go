@@ -26,6 +26,7 @@ import (
 
 	"github.com/openfga/cli/internal/cmdutils"
 	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
 )
 
 // listObjects in the internal function for calling SDK for list objects.
@@ -68,7 +69,11 @@ var listObjectsCmd = &cobra.Command{
 	Example: `fga query list-objects --store-id=01H0H015178Y2V4CX10C2KGHF4 user:anne can_view document --contextual-tuple "user:anne can_view folder:product" --contextual-tuple "folder:product parent document:roadmap" --consistency "HIGHER_CONSISTENCY"`, //nolint:lll
 	Args:    cobra.ExactArgs(3),                                                                                                                                                                                                                               //nolint:mnd,lll
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clientConfig := cmdutils.GetClientConfig(cmd)
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
 
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
@@ -97,9 +102,22 @@ var listObjectsCmd = &cobra.Command{
 			return err
 		}
 
-		return output.Display(*response)
+		if err := output.Display(*response); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return recordResult(cmd, func(fileName string, testName string) (string, error) {
+			return storetest.RecordListObjects(fileName, testName, client.ClientListObjectsRequest{
+				User:             args[0],
+				Relation:         args[1],
+				Type:             args[2],
+				ContextualTuples: contextualTuples,
+				Context:          queryContext,
+			}, response.GetObjects())
+		})
 	},
 }
 
 func init() {
+	addRecordFlags(listObjectsCmd)
 }
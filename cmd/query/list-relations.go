@@ -132,7 +132,11 @@ var listRelationsCmd = &cobra.Command{
 	Example: `fga query list-relations --store-id=01H0H015178Y2V4CX10C2KGHF4 user:anne document:roadmap --relation can_view --consistency "HIGHER_CONSISTENCY"`, //nolint:lll
 	Args:    cobra.ExactArgs(2),                                                                                                                                 //nolint:mnd,lll
 	RunE: func(cmd *cobra.Command, args []string) error {
-		clientConfig := cmdutils.GetClientConfig(cmd)
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
 
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
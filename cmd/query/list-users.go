@@ -28,6 +28,7 @@ import (
 
 	"github.com/openfga/cli/internal/cmdutils"
 	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
 )
 
 func parseUserFilters(rawUserFilter string) []openfga.UserTypeFilter {
@@ -97,7 +98,11 @@ var listUsersCmd = &cobra.Command{
 	Long:    "List all users that have a certain relation with a particular object",
 	Example: `fga query list-users --store-id=01H0H015178Y2V4CX10C2KGHF4 --object document:roadmap --relation can_view --consistency "HIGHER_CONSISTENCY"`, //nolint:lll
 	RunE: func(cmd *cobra.Command, _ []string) error {
-		clientConfig := cmdutils.GetClientConfig(cmd)
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
 
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
@@ -130,7 +135,19 @@ var listUsersCmd = &cobra.Command{
 			return err
 		}
 
-		return output.Display(*response)
+		if err := output.Display(*response); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return recordResult(cmd, func(fileName string, testName string) (string, error) {
+			return storetest.RecordListUsers(fileName, testName, client.ClientListUsersRequest{
+				Object:           parseObject(object),
+				Relation:         relation,
+				UserFilters:      parseUserFilters(userFilter),
+				ContextualTuples: contextualTuples,
+				Context:          queryContext,
+			}, response.GetUsers())
+		})
 	},
 }
 
@@ -138,6 +155,7 @@ func init() {
 	listUsersCmd.Flags().String("object", "", "Object to list users for")
 	listUsersCmd.Flags().String("relation", "", "Relation to evaluate on")
 	listUsersCmd.Flags().String("user-filter", "", "Filter the responses can be in the formats <type> (to filter objects and typed public bound access) or <type>#<relation> (to filter usersets)") //nolint:lll
+	addRecordFlags(listUsersCmd)
 
 	if err := listUsersCmd.MarkFlagRequired("object"); err != nil {
 		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/query/list-users", err)
@@ -0,0 +1,66 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/localserver"
+)
+
+// getQueryClientConfig returns the configuration of the client the query runs with. With
+// --local-store, the store file is loaded into an embedded server, and the configuration points
+// at it until the returned function is called.
+func getQueryClientConfig(cmd *cobra.Command) (fga.ClientConfig, func(), error) {
+	clientConfig := cmdutils.GetClientConfig(cmd)
+
+	localStoreFile, _ := cmd.Flags().GetString("local-store")
+	if localStoreFile == "" {
+		return clientConfig, func() {}, nil
+	}
+
+	allowExternalFiles, _ := cmd.Flags().GetBool("allow-external-files")
+	maxTypes, _ := cmd.Flags().GetInt("max-types-per-authorization-model")
+
+	localServer, _, localClientConfig, err := localserver.StartWithStoreFile(cmd.Context(), localserver.Config{
+		Addr:                          localserver.DefaultAddr,
+		MaxTypesPerAuthorizationModel: maxTypes,
+	}, localStoreFile, allowExternalFiles)
+	if err != nil {
+		return clientConfig, func() {}, fmt.Errorf("failed to load local store %s due to %w", localStoreFile, err)
+	}
+
+	if clientConfig.Debug {
+		fmt.Fprintf(os.Stderr, "Answering from local store %s loaded from %s\n", localClientConfig.StoreID, localStoreFile)
+	}
+
+	clientConfig.ApiUrl = localClientConfig.ApiUrl
+	clientConfig.StoreID = localClientConfig.StoreID
+	clientConfig.AuthorizationModelID = localClientConfig.AuthorizationModelID
+	// The embedded server takes no credentials
+	clientConfig.APIToken = ""
+	clientConfig.APITokenIssuer = ""
+	clientConfig.ClientID = ""
+	clientConfig.ClientSecret = ""
+
+	return clientConfig, localServer.Close, nil
+}
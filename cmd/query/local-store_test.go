@@ -0,0 +1,126 @@
+package query
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/fga"
+)
+
+const localStoreFile = `name: local
+model: |
+  model
+    schema 1.1
+  type user
+  type document
+    relations
+      define owner: [user]
+      define viewer: [user] or owner
+tuples:
+  - user: user:anne
+    relation: owner
+    object: document:roadmap
+`
+
+// remoteStoreID is the --store-id that --local-store takes the place of in the tests.
+const remoteStoreID = "01H0H015178Y2V4CX10C2KGHF4"
+
+// newLocalStoreClient loads the store file into an embedded server with --local-store, and
+// returns the client configuration the query runs with and a client for it. The server is
+// stopped when the test finishes.
+func newLocalStoreClient(t *testing.T, storeYAML string) (fga.ClientConfig, *client.OpenFgaClient) {
+	t.Helper()
+
+	fileName := filepath.Join(t.TempDir(), "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(storeYAML), 0o600))
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(t.Context())
+	cmd.Flags().String("api-url", "https://api.fga.example", "")
+	cmd.Flags().String("store-id", remoteStoreID, "")
+	cmd.Flags().String("local-store", fileName, "")
+	cmd.Flags().Int("max-types-per-authorization-model", 100, "")
+	cmd.Flags().Bool("allow-external-files", false, "")
+
+	clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+	require.NoError(t, err)
+	t.Cleanup(stopLocalStore)
+
+	fgaClient, err := clientConfig.GetFgaClient()
+	require.NoError(t, err)
+
+	return clientConfig, fgaClient
+}
+
+func TestGetQueryClientConfigWithLocalStore(t *testing.T) {
+	t.Parallel()
+
+	clientConfig, fgaClient := newLocalStoreClient(t, localStoreFile)
+
+	assert.NotEqual(t, "https://api.fga.example", clientConfig.ApiUrl)
+	assert.NotEqual(t, remoteStoreID, clientConfig.StoreID)
+	assert.NotEmpty(t, clientConfig.AuthorizationModelID)
+
+	response, err := check(
+		t.Context(), fgaClient, "user:anne", "viewer", "document:roadmap", nil, nil,
+		openfga.CONSISTENCYPREFERENCE_UNSPECIFIED.Ptr(),
+	)
+	require.NoError(t, err)
+	assert.True(t, response.GetAllowed())
+
+	relations, err := listRelations(
+		t.Context(), clientConfig, fgaClient, "user:anne", "document:roadmap", nil, nil, nil,
+		openfga.CONSISTENCYPREFERENCE_UNSPECIFIED.Ptr(),
+	)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"owner", "viewer"}, relations.Relations)
+}
+
+func TestGetQueryClientConfigWithLocalStoreExternalFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "model.fga"), []byte("model\n  schema 1.1\ntype user\n"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "stores"), 0o700))
+
+	fileName := filepath.Join(dir, "stores", "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte("name: local\nmodel_file: ../model.fga\n"), 0o600))
+
+	for _, allowExternalFiles := range []bool{false, true} {
+		cmd := &cobra.Command{}
+		cmd.SetContext(t.Context())
+		cmd.Flags().String("local-store", fileName, "")
+		cmd.Flags().Int("max-types-per-authorization-model", 100, "")
+		cmd.Flags().Bool("allow-external-files", allowExternalFiles, "")
+
+		_, stopLocalStore, err := getQueryClientConfig(cmd)
+		stopLocalStore()
+
+		if allowExternalFiles {
+			require.NoError(t, err)
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestGetQueryClientConfigWithoutLocalStore(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("store-id", remoteStoreID, "")
+	cmd.Flags().String("local-store", "", "")
+
+	clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+	require.NoError(t, err)
+
+	stopLocalStore()
+	assert.Equal(t, remoteStoreID, clientConfig.StoreID)
+}
@@ -0,0 +1,381 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/tuple"
+)
+
+const (
+	matrixCellAllowed = "allowed"
+	matrixCellDenied  = "denied"
+	matrixCellError   = "error"
+)
+
+var matrixOutputFormats = []string{"csv", "markdown", "html"}
+
+const matrixHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Access matrix</title>
+<style>
+table { border-collapse: collapse; font-family: sans-serif; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+td.allowed { background: #d4f7d4; }
+td.denied { background: #f7d4d4; }
+td.error { background: #f7ecc4; }
+</style>
+</head>
+<body>
+<table>
+<thead>
+<tr><th>user</th>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+</thead>
+<tbody>
+{{range .Rows}}<tr><th>{{.User}}</th>{{range .Cells}}<td class="{{.Value}}"{{if .Error}} title="{{.Error}}"{{end}}>{{.Value}}</td>{{end}}</tr>
+{{end}}</tbody>
+</table>
+</body>
+</html>
+`
+
+// accessMatrix holds the result of the check of every user, object and relation. There is a
+// row per user, and a column per object and relation.
+type accessMatrix struct {
+	users     []string
+	objects   []string
+	relations []string
+	results   []checkFileResult
+}
+
+type accessMatrixCell struct {
+	Value string
+	Error string
+}
+
+type accessMatrixRow struct {
+	User  string
+	Cells []accessMatrixCell
+}
+
+// readMatrixLines reads a file with a value per line. Empty lines and lines starting with "#"
+// are skipped.
+func readMatrixLines(fileName string) ([]string, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", fileName, err)
+	}
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read file %q: %w", fileName, err)
+	}
+
+	return lines, nil
+}
+
+func matrixChecks(users []string, objects []string, relations []string) []client.ClientBatchCheckItem {
+	checks := make([]client.ClientBatchCheckItem, 0, len(users)*len(objects)*len(relations))
+
+	for _, user := range users {
+		for _, object := range objects {
+			for _, relation := range relations {
+				checks = append(checks, client.ClientBatchCheckItem{
+					User:          user,
+					Relation:      relation,
+					Object:        object,
+					CorrelationId: strconv.Itoa(len(checks)),
+				})
+			}
+		}
+	}
+
+	return checks
+}
+
+func (matrix *accessMatrix) columns() []string {
+	columns := make([]string, 0, len(matrix.objects)*len(matrix.relations))
+
+	for _, object := range matrix.objects {
+		for _, relation := range matrix.relations {
+			columns = append(columns, object+"#"+relation)
+		}
+	}
+
+	return columns
+}
+
+func (matrix *accessMatrix) rows() []accessMatrixRow {
+	rows := make([]accessMatrixRow, 0, len(matrix.users))
+	columnCount := len(matrix.objects) * len(matrix.relations)
+
+	for userIndex, user := range matrix.users {
+		row := accessMatrixRow{User: user, Cells: make([]accessMatrixCell, 0, columnCount)}
+
+		for _, result := range matrix.results[userIndex*columnCount : (userIndex+1)*columnCount] {
+			switch {
+			case result.Allowed == nil:
+				row.Cells = append(row.Cells, accessMatrixCell{Value: matrixCellError, Error: result.Error})
+			case *result.Allowed:
+				row.Cells = append(row.Cells, accessMatrixCell{Value: matrixCellAllowed})
+			default:
+				row.Cells = append(row.Cells, accessMatrixCell{Value: matrixCellDenied})
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func (matrix *accessMatrix) render(format string, writer io.Writer) error {
+	switch format {
+	case "markdown":
+		return matrix.renderMarkdown(writer)
+	case "html":
+		return matrix.renderHTML(writer)
+	default:
+		return matrix.renderCSV(writer)
+	}
+}
+
+func (matrix *accessMatrix) renderCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+
+	if err := csvWriter.Write(append([]string{"user"}, matrix.columns()...)); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, row := range matrix.rows() {
+		record := []string{row.User}
+		for _, cell := range row.Cells {
+			record = append(record, cell.Value)
+		}
+
+		if err := csvWriter.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	csvWriter.Flush()
+
+	if err := csvWriter.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	return nil
+}
+
+func (matrix *accessMatrix) renderMarkdown(writer io.Writer) error {
+	escape := strings.NewReplacer("|", `\|`).Replace
+	columns := matrix.columns()
+
+	var builder strings.Builder
+
+	builder.WriteString("| user |")
+
+	for _, column := range columns {
+		builder.WriteString(" " + escape(column) + " |")
+	}
+
+	builder.WriteString("\n|---|" + strings.Repeat("---|", len(columns)) + "\n")
+
+	for _, row := range matrix.rows() {
+		builder.WriteString("| " + escape(row.User) + " |")
+
+		for _, cell := range row.Cells {
+			builder.WriteString(" " + cell.Value + " |")
+		}
+
+		builder.WriteString("\n")
+	}
+
+	if _, err := io.WriteString(writer, builder.String()); err != nil {
+		return fmt.Errorf("failed to write markdown: %w", err)
+	}
+
+	return nil
+}
+
+func (matrix *accessMatrix) renderHTML(writer io.Writer) error {
+	htmlTemplate := template.Must(template.New("matrix").Parse(matrixHTMLTemplate))
+
+	err := htmlTemplate.Execute(writer, map[string]any{
+		"Columns": matrix.columns(),
+		"Rows":    matrix.rows(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write html: %w", err)
+	}
+
+	return nil
+}
+
+// errorCount returns the number of checks that failed, and the error of the first one.
+func (matrix *accessMatrix) errorCount() (int, string) {
+	count := 0
+	firstError := ""
+
+	for _, result := range matrix.results {
+		if result.Allowed == nil {
+			if count == 0 {
+				firstError = result.Error
+			}
+
+			count++
+		}
+	}
+
+	return count, firstError
+}
+
+func writeMatrixOutput(matrix *accessMatrix, format string, outputFile string) error {
+	if outputFile == "" {
+		return matrix.render(format, os.Stdout)
+	}
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return fmt.Errorf("failed to create output file due to %w", err)
+	}
+	defer file.Close()
+
+	if err := matrix.render(format, file); err != nil {
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write output file due to %w", err)
+	}
+
+	return nil
+}
+
+// matrixCmd represents the matrix command.
+var matrixCmd = &cobra.Command{
+	Use:   "matrix",
+	Short: "Access matrix",
+	Long: `Check every relation of every user on every object, and render the result as a grid with a row per user and a column per object and relation.
+
+The users and objects files hold one value per line; empty lines and lines starting with "#" are skipped. The checks are sent in batches, like those of "fga query check --file".`, //nolint:lll
+	Example: `fga query matrix --store-id=01H0H015178Y2V4CX10C2KGHF4 --users users.txt --objects objects.txt --relations viewer,editor
+fga query matrix --local-store store.fga.yaml --users users.txt --objects objects.txt --relations viewer --output-format html --output-file matrix.html`, //nolint:lll
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		usersFile, _ := cmd.Flags().GetString("users")
+		objectsFile, _ := cmd.Flags().GetString("objects")
+		relations, _ := cmd.Flags().GetStringSlice("relations")
+		outputFormat, _ := cmd.Flags().GetString("output-format")
+		outputFile, _ := cmd.Flags().GetString("output-file")
+
+		for index := range relations {
+			relations[index] = strings.TrimSpace(relations[index])
+		}
+
+		if !slices.Contains(matrixOutputFormats, outputFormat) {
+			return clierrors.ValidationError("query matrix", fmt.Sprintf( //nolint:wrapcheck
+				`unsupported output format %q, supported formats are "csv", "markdown" and "html"`, outputFormat))
+		}
+
+		options, err := getCheckFileOptions(cmd)
+		if err != nil {
+			return err
+		}
+
+		users, err := readMatrixLines(usersFile)
+		if err != nil {
+			return err
+		}
+
+		objects, err := readMatrixLines(objectsFile)
+		if err != nil {
+			return err
+		}
+
+		clientConfig, stopLocalStore, err := getQueryClientConfig(cmd)
+		if err != nil {
+			return err
+		}
+		defer stopLocalStore()
+
+		fgaClient, err := clientConfig.GetFgaClient()
+		if err != nil {
+			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+		}
+
+		results, err := checkFile(cmd.Context(), fgaClient, matrixChecks(users, objects, relations), *options)
+		if err != nil {
+			return err
+		}
+
+		matrix := &accessMatrix{users: users, objects: objects, relations: relations, results: results}
+
+		if count, firstError := matrix.errorCount(); count > 0 {
+			fmt.Fprintf(os.Stderr, "Warning: %d of %d checks failed and are marked as %q, the first one with: %s\n",
+				count, len(results), matrixCellError, firstError)
+		}
+
+		return writeMatrixOutput(matrix, outputFormat, outputFile)
+	},
+}
+
+func init() {
+	matrixCmd.Flags().String("users", "", "File with a user per line")
+	matrixCmd.Flags().String("objects", "", "File with an object per line")
+	matrixCmd.Flags().StringSlice("relations", []string{}, "Relations to check, comma separated")
+	matrixCmd.Flags().String("output-format", "csv", `Output format. Can be "csv", "markdown" or "html"`)
+	matrixCmd.Flags().String("output-file", "", "File to write the matrix to, instead of the standard output")
+	matrixCmd.Flags().Int("max-checks-per-batch", defaultMaxChecksPerBatch, "Max checks per BatchCheck request.")
+	matrixCmd.Flags().Int("max-parallel-requests", tuple.MaxParallelRequests, "Max number of requests to issue to the server in parallel.") //nolint:lll
+	matrixCmd.Flags().Int("max-rps", 0, "The maximum requests per second.")
+	matrixCmd.Flags().Int("rampup-period-in-sec", 0, "The period over which to ramp up the request rate.")
+
+	for _, flagName := range []string{"users", "objects", "relations"} {
+		if err := matrixCmd.MarkFlagRequired(flagName); err != nil {
+			fmt.Printf("error setting flag as required - %v: %v\n", "cmd/query/matrix", err)
+			os.Exit(1)
+		}
+	}
+}
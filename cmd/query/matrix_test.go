@@ -0,0 +1,68 @@
+package query
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadMatrixLines(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "users.txt")
+	require.NoError(t, os.WriteFile(fileName, []byte("# reviewers\nuser:anne\n\n  user:beth  \n"), 0o600))
+
+	lines, err := readMatrixLines(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:anne", "user:beth"}, lines)
+}
+
+func TestAccessMatrix(t *testing.T) {
+	t.Parallel()
+
+	_, fgaClient := newLocalStoreClient(t, localStoreFile)
+
+	users := []string{"user:anne", "user:beth"}
+	objects := []string{"document:roadmap"}
+	relations := []string{"owner", "viewer", "editor"}
+
+	results, err := checkFile(t.Context(), fgaClient, matrixChecks(users, objects, relations), checkFileOptions{
+		maxChecksPerBatch:   defaultMaxChecksPerBatch,
+		maxParallelRequests: 1,
+		consistency:         openfga.CONSISTENCYPREFERENCE_UNSPECIFIED.Ptr(),
+	})
+	require.NoError(t, err)
+
+	matrix := &accessMatrix{users: users, objects: objects, relations: relations, results: results}
+
+	count, firstError := matrix.errorCount()
+	assert.Equal(t, 2, count)
+	assert.Contains(t, firstError, "editor")
+
+	var csvOutput bytes.Buffer
+
+	require.NoError(t, matrix.render("csv", &csvOutput))
+	assert.Equal(t, `user,document:roadmap#owner,document:roadmap#viewer,document:roadmap#editor
+user:anne,allowed,allowed,error
+user:beth,denied,denied,error
+`, csvOutput.String())
+
+	var markdownOutput bytes.Buffer
+
+	require.NoError(t, matrix.render("markdown", &markdownOutput))
+	assert.Equal(t, `| user | document:roadmap#owner | document:roadmap#viewer | document:roadmap#editor |
+|---|---|---|---|
+| user:anne | allowed | allowed | error |
+| user:beth | denied | denied | error |
+`, markdownOutput.String())
+
+	var htmlOutput bytes.Buffer
+
+	require.NoError(t, matrix.render("html", &htmlOutput))
+	assert.Contains(t, htmlOutput.String(), `<tr><th>user:anne</th><td class="allowed">allowed</td><td class="allowed">allowed</td><td class="error" title="invalid relation`)
+}
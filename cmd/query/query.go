@@ -18,25 +18,26 @@ limitations under the License.
 package query
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/localserver"
 )
 
 // QueryCmd represents the query command.
 var QueryCmd = &cobra.Command{
 	Use:   "query",
 	Short: "Run Queries",
-	Long:  "Run queries (Check, Expand, ListObjects, ListRelations, ListUsers) that are evaluated according to a particular model.", //nolint:lll
+	Long:  "Run queries (Check, Expand, Explain, ListObjects, ListRelations, ListUsers) that are evaluated according to a particular model.", //nolint:lll
 }
 
 func init() {
 	QueryCmd.AddCommand(checkCmd)
 	QueryCmd.AddCommand(expandCmd)
+	QueryCmd.AddCommand(explainCmd)
 	QueryCmd.AddCommand(listObjectsCmd)
 	QueryCmd.AddCommand(listRelationsCmd)
 	QueryCmd.AddCommand(listUsersCmd)
+	QueryCmd.AddCommand(matrixCmd)
 
 	QueryCmd.PersistentFlags().String("store-id", "", "Store ID")
 	QueryCmd.PersistentFlags().String("model-id", "", "Model ID")
@@ -47,10 +48,18 @@ func init() {
 		"",
 		"Consistency preference for the request. Valid options are HIGHER_CONSISTENCY and MINIMIZE_LATENCY.",
 	)
+	QueryCmd.PersistentFlags().String(
+		"local-store",
+		"",
+		"Store file whose model and tuples are loaded into an embedded server to answer the query, instead of --store-id",
+	)
+
+	QueryCmd.PersistentFlags().Int(
+		"max-types-per-authorization-model",
+		localserver.DefaultMaxTypesPerAuthorizationModel,
+		"Maximum number of types allowed in the model loaded from --local-store",
+	)
+	QueryCmd.PersistentFlags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the --local-store file to resolve to paths outside its directory. Only enable this for store files you trust.") //nolint:lll
 
-	err := QueryCmd.MarkPersistentFlagRequired("store-id")
-	if err != nil {
-		fmt.Print(err)
-		os.Exit(1)
-	}
+	QueryCmd.MarkFlagsOneRequired("store-id", "local-store")
 }
@@ -0,0 +1,51 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package query
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const defaultRecordTestName = "recorded"
+
+func addRecordFlags(cmd *cobra.Command) {
+	cmd.Flags().String("record", "", "Test file to append the request and its result to, as an assertion of a test")             //nolint:lll
+	cmd.Flags().String("record-test", defaultRecordTestName, "Name of the test in the --record file to append the assertion to") //nolint:lll
+}
+
+// recordResult calls record with the test file and test name when --record is set. record returns
+// the name of the test it recorded to.
+func recordResult(cmd *cobra.Command, record func(fileName string, testName string) (string, error)) error {
+	fileName, _ := cmd.Flags().GetString("record")
+	if fileName == "" {
+		return nil
+	}
+
+	testName, _ := cmd.Flags().GetString("record-test")
+
+	testName, err := record(fileName, testName)
+	if err != nil {
+		return fmt.Errorf("failed to record the result due to %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "recorded in test %q of %s\n", testName, fileName)
+
+	return nil
+}
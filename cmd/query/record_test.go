@@ -0,0 +1,38 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errMockRecord = errors.New("mock error")
+
+func TestRecordResult(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	addRecordFlags(cmd)
+
+	called := false
+	require.NoError(t, recordResult(cmd, func(string, string) (string, error) {
+		called = true
+
+		return "", nil
+	}))
+	assert.False(t, called, "nothing is recorded without --record")
+
+	require.NoError(t, cmd.Flags().Set("record", "tests.fga.yaml"))
+	require.NoError(t, recordResult(cmd, func(fileName string, testName string) (string, error) {
+		assert.Equal(t, "tests.fga.yaml", fileName)
+		assert.Equal(t, defaultRecordTestName, testName)
+
+		return testName, nil
+	}))
+
+	err := recordResult(cmd, func(string, string) (string, error) { return "", errMockRecord })
+	require.ErrorIs(t, err, errMockRecord)
+}
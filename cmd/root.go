@@ -26,13 +26,24 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/openfga/cli/cmd/model"
+	"github.com/openfga/cli/cmd/profile"
 	"github.com/openfga/cli/cmd/query"
+	"github.com/openfga/cli/cmd/server"
+	"github.com/openfga/cli/cmd/shell"
 	"github.com/openfga/cli/cmd/store"
 	"github.com/openfga/cli/cmd/tuple"
 	"github.com/openfga/cli/internal/cmdutils"
+	internalprofile "github.com/openfga/cli/internal/profile"
 )
 
-var cfgFile string
+var (
+	cfgFile     string
+	profileName string
+
+	// profileErr is the error applying the selected profile, returned by every command
+	// other than the profile commands, which are needed to fix it.
+	profileErr error
+)
 
 // rootCmd represents the base command when called without any subcommands.
 var rootCmd = &cobra.Command{
@@ -40,6 +51,23 @@ var rootCmd = &cobra.Command{
 	Short:        "OpenFGA CLI",
 	Long:         ``,
 	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		if profileErr != nil && !isProfileCommand(cmd) {
+			return profileErr
+		}
+
+		return nil
+	},
+}
+
+func isProfileCommand(cmd *cobra.Command) bool {
+	for ; cmd != nil; cmd = cmd.Parent() {
+		if cmd == profile.ProfileCmd {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -55,6 +83,7 @@ func init() {
 	cobra.OnInitialize(initConfig)
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.fga.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Name of the connection profile from the config file to use") //nolint:lll
 
 	// 'server-url' is deprecated in favor of 'api-url' for consistency with the SDKs,
 	// it is still kept here for backward compatibility
@@ -85,6 +114,9 @@ func init() {
 	rootCmd.AddCommand(model.ModelCmd)
 	rootCmd.AddCommand(tuple.TupleCmd)
 	rootCmd.AddCommand(query.QueryCmd)
+	rootCmd.AddCommand(profile.ProfileCmd)
+	rootCmd.AddCommand(shell.ShellCmd)
+	rootCmd.AddCommand(server.ServerCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -120,6 +152,9 @@ func initConfig() {
 	// If a config file is found, read it in.
 	if err := viperInstance.ReadInConfig(); err == nil {
 		fmt.Fprintln(os.Stderr, "Using config file:", viperInstance.ConfigFileUsed())
+
+		// Let the profile commands edit the file that was found
+		cfgFile = viperInstance.ConfigFileUsed()
 	}
 
 	viperInstance.SetEnvPrefix("FGA")
@@ -127,5 +162,8 @@ func initConfig() {
 
 	viperInstance.AutomaticEnv() // read in environment variables that match
 
+	// Apply the selected profile on top of the top-level config keys
+	profileErr = internalprofile.Apply(viperInstance, internalprofile.Selected(viperInstance, profileName))
+
 	cmdutils.BindViperToFlags(rootCmd, viperInstance)
 }
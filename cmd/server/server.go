@@ -0,0 +1,33 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package server contains commands that run a local OpenFGA server.
+package server
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ServerCmd represents the server command.
+var ServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Run a local server",
+	Long:  "Run an embedded OpenFGA server on localhost, backed by an in-memory datastore.",
+}
+
+func init() {
+	ServerCmd.AddCommand(startCmd)
+}
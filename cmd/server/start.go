@@ -0,0 +1,141 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/localserver"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+const (
+	defaultHost = "localhost"
+	defaultPort = 8080
+
+	maxAssertionsPerWrite = 100
+)
+
+type startServerResponse struct {
+	APIURL string `json:"api_url"`
+	localserver.LoadedStore
+}
+
+// startServer starts the local server on addr and loads the store file's model, tuples and check
+// assertions into it.
+func startServer(
+	ctx context.Context,
+	config localserver.Config,
+	fileName string,
+	allowExternalFiles bool,
+) (*localserver.Server, *startServerResponse, error) {
+	localServer, storeData, clientConfig, err := localserver.StartWithStoreFile(ctx, config, fileName, allowExternalFiles)
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	loadedStore := &localserver.LoadedStore{StoreID: clientConfig.StoreID, ModelID: clientConfig.AuthorizationModelID}
+
+	if loadedStore.ModelID != "" {
+		assertions := []client.ClientAssertion{}
+		for _, test := range storeData.Tests {
+			assertions = append(assertions, storetest.GetCheckAssertions(test.Check)...)
+		}
+
+		if len(assertions) > maxAssertionsPerWrite {
+			fmt.Fprintf(os.Stderr, "Warning: %d test assertions found, but only the first %d will be written\n",
+				len(assertions), maxAssertionsPerWrite)
+
+			assertions = assertions[:maxAssertionsPerWrite]
+		}
+
+		if len(assertions) > 0 {
+			if err := localServer.WriteAssertions(ctx, loadedStore, assertions); err != nil {
+				localServer.Close()
+
+				return nil, nil, err //nolint:wrapcheck
+			}
+		}
+	}
+
+	return localServer, &startServerResponse{APIURL: localServer.APIURL(), LoadedStore: *loadedStore}, nil
+}
+
+// startCmd represents the server start command.
+var startCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a local server preloaded with a store file",
+	Long: `Start an embedded OpenFGA server that serves the HTTP API on localhost, preloaded with the model, tuples and check assertions of the store file.
+
+The server keeps its data in memory: changes are lost when it stops. Press Ctrl+C to stop it.`, //nolint:lll
+	Example: `fga server start --file store.fga.yaml
+fga server start --file store.fga.yaml --port 8080`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		fileName, _ := cmd.Flags().GetString("file")
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetInt("port")
+		maxTypes, _ := cmd.Flags().GetInt("max-types-per-authorization-model")
+		allowExternalFiles, _ := cmd.Flags().GetBool("allow-external-files")
+
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		localServer, response, err := startServer(ctx, localserver.Config{
+			Addr:                          net.JoinHostPort(host, strconv.Itoa(port)),
+			MaxTypesPerAuthorizationModel: maxTypes,
+		}, fileName, allowExternalFiles)
+		if err != nil {
+			return err
+		}
+		defer localServer.Close()
+
+		if err := output.Display(response); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		fmt.Fprintf(os.Stderr, "Serving %s on %s, press Ctrl+C to stop\n", fileName, response.APIURL)
+
+		<-ctx.Done()
+
+		return nil
+	},
+}
+
+func init() {
+	startCmd.Flags().String("file", "", "Store file to load into the server")
+	startCmd.Flags().String("host", defaultHost, "Host the HTTP API listens on")
+	startCmd.Flags().Int("port", defaultPort, "Port the HTTP API listens on (0 picks a free port)")
+	startCmd.Flags().Int("max-types-per-authorization-model", localserver.DefaultMaxTypesPerAuthorizationModel,
+		"Maximum number of types allowed in the model of the store file")
+	startCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
+
+	if err := startCmd.MarkFlagRequired("file"); err != nil {
+		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/server/start", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/localserver"
+)
+
+const testStoreFile = `name: docs
+model: |
+  model
+    schema 1.1
+  type user
+  type document
+    relations
+      define viewer: [user]
+tuples:
+  - user: user:anne
+    relation: viewer
+    object: document:1
+tests:
+  - name: viewers
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: true
+`
+
+func TestStartServer(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(testStoreFile), 0o600))
+
+	localServer, response, err := startServer(t.Context(), localserver.Config{
+		Addr:                          localserver.DefaultAddr,
+		MaxTypesPerAuthorizationModel: localserver.DefaultMaxTypesPerAuthorizationModel,
+	}, fileName, false)
+	require.NoError(t, err)
+	t.Cleanup(localServer.Close)
+
+	assert.Equal(t, localServer.APIURL(), response.APIURL)
+	assert.NotEmpty(t, response.ModelID)
+
+	fgaClient, err := localServer.ClientConfig(response.StoreID, response.ModelID).GetFgaClient()
+	require.NoError(t, err)
+
+	check, err := fgaClient.Check(t.Context()).Body(client.ClientCheckRequest{
+		User: "user:anne", Relation: "viewer", Object: "document:1",
+	}).Execute()
+	require.NoError(t, err)
+	assert.True(t, check.GetAllowed())
+
+	assertions, err := fgaClient.ReadAssertions(t.Context()).Execute()
+	require.NoError(t, err)
+	require.Len(t, assertions.GetAssertions(), 1)
+	assert.Equal(t, "user:anne", assertions.GetAssertions()[0].TupleKey.User)
+	assert.True(t, assertions.GetAssertions()[0].Expectation)
+}
@@ -0,0 +1,473 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/lineeditor"
+	"github.com/openfga/cli/internal/output"
+)
+
+const prompt = "fga> "
+
+var errExit = errors.New("exit")
+
+// argumentKind is what an argument of a shell command is, to know what to complete it with.
+type argumentKind int
+
+const (
+	argumentUser argumentKind = iota
+	argumentRelation
+	argumentObject
+	argumentType
+	argumentUserFilter
+)
+
+type shellCommand struct {
+	name        string
+	usage       string
+	description string
+	// arguments are the required arguments, followed by the optional ones
+	arguments         []argumentKind
+	requiredArguments int
+	// variadic repeats the last argument
+	variadic bool
+	run      func(session *session, ctx context.Context, args []string) error //nolint:revive
+}
+
+// shellCommands is set in init, as the help command lists it.
+var shellCommands []shellCommand
+
+func init() { //nolint:funlen
+	shellCommands = []shellCommand{
+		{
+			name:              "check",
+			usage:             "check <user> <relation> <object>",
+			description:       "Check if a user has a particular relation with an object",
+			arguments:         []argumentKind{argumentUser, argumentRelation, argumentObject},
+			requiredArguments: 3, //nolint:mnd
+			run:               (*session).check,
+		},
+		{
+			name:              "list-objects",
+			usage:             "list-objects <user> <relation> <type>",
+			description:       "List the objects of a type that a user has a particular relation to",
+			arguments:         []argumentKind{argumentUser, argumentRelation, argumentType},
+			requiredArguments: 3, //nolint:mnd
+			run:               (*session).listObjects,
+		},
+		{
+			name:              "list-users",
+			usage:             "list-users <object> <relation> [<type> | <type>#<relation>]",
+			description:       "List the users that have a particular relation with an object",
+			arguments:         []argumentKind{argumentObject, argumentRelation, argumentUserFilter},
+			requiredArguments: 2, //nolint:mnd
+			run:               (*session).listUsers,
+		},
+		{
+			name:              "list-relations",
+			usage:             "list-relations <user> <object> [<relation>...]",
+			description:       "List the relations a user has with an object",
+			arguments:         []argumentKind{argumentUser, argumentObject, argumentRelation},
+			requiredArguments: 2, //nolint:mnd
+			variadic:          true,
+			run:               (*session).listRelations,
+		},
+		{
+			name:              "expand",
+			usage:             "expand <relation> <object>",
+			description:       "Expand the relationships of an object in userset tree format",
+			arguments:         []argumentKind{argumentRelation, argumentObject},
+			requiredArguments: 2, //nolint:mnd
+			run:               (*session).expand,
+		},
+		{
+			name:              "write",
+			usage:             "write <user> <relation> <object>",
+			description:       "Write a relationship tuple",
+			arguments:         []argumentKind{argumentUser, argumentRelation, argumentObject},
+			requiredArguments: 3, //nolint:mnd
+			run:               (*session).write,
+		},
+		{
+			name:              "delete",
+			usage:             "delete <user> <relation> <object>",
+			description:       "Delete a relationship tuple",
+			arguments:         []argumentKind{argumentUser, argumentRelation, argumentObject},
+			requiredArguments: 3, //nolint:mnd
+			run:               (*session).delete,
+		},
+		{
+			name:        "history",
+			usage:       "history",
+			description: "Show the commands run so far",
+			run:         (*session).showHistory,
+		},
+		{
+			name:        "help",
+			usage:       "help",
+			description: "Show the available commands",
+			run:         (*session).help,
+		},
+		{
+			name:        "exit",
+			usage:       "exit",
+			description: "Leave the shell (or press Ctrl+D)",
+			run: func(*session, context.Context, []string) error {
+				return errExit
+			},
+		},
+	}
+}
+
+func findShellCommand(name string) *shellCommand {
+	if name == "quit" {
+		name = "exit"
+	}
+
+	for index := range shellCommands {
+		if shellCommands[index].name == name {
+			return &shellCommands[index]
+		}
+	}
+
+	return nil
+}
+
+// session holds the client and model that the commands of a shell run against.
+type session struct {
+	fgaClient client.SdkClient
+	// relations maps each type of the model to its relations
+	relations map[string][]string
+	editor    *lineeditor.Editor
+	output    io.Writer
+}
+
+func newSession(fgaClient client.SdkClient, model *openfga.AuthorizationModel, output io.Writer) *session {
+	shellSession := &session{
+		fgaClient: fgaClient,
+		relations: map[string][]string{},
+		output:    output,
+	}
+
+	if model != nil {
+		for _, typeDef := range model.GetTypeDefinitions() {
+			relations := []string{}
+			for relation := range typeDef.GetRelations() {
+				relations = append(relations, relation)
+			}
+
+			slices.Sort(relations)
+			shellSession.relations[typeDef.GetType()] = relations
+		}
+	}
+
+	return shellSession
+}
+
+// run reads and runs commands until the input is exhausted or the exit command is run. A failing
+// command is reported, and does not end the session.
+func (session *session) run(ctx context.Context) error {
+	for {
+		line, err := session.editor.ReadLine(prompt)
+		if errors.Is(err, lineeditor.ErrInterrupted) {
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read command due to %w", err)
+		}
+
+		session.editor.AddHistory(line)
+
+		err = session.execute(ctx, line)
+		if errors.Is(err, errExit) {
+			return nil
+		}
+
+		if err != nil {
+			fmt.Fprintf(session.output, "Error: %v\n", err)
+		}
+	}
+}
+
+func (session *session) execute(ctx context.Context, line string) error {
+	words := strings.Fields(line)
+	if len(words) == 0 || strings.HasPrefix(words[0], "#") {
+		return nil
+	}
+
+	command := findShellCommand(words[0])
+	if command == nil {
+		return clierrors.ValidationError("shell", fmt.Sprintf("unknown command %q, run \"help\" to list the commands", words[0])) //nolint:lll,wrapcheck
+	}
+
+	args := words[1:]
+	if len(args) < command.requiredArguments || (!command.variadic && len(args) > len(command.arguments)) {
+		return clierrors.ValidationError(command.name, "usage: "+command.usage) //nolint:wrapcheck
+	}
+
+	return command.run(session, ctx, args)
+}
+
+func (session *session) check(ctx context.Context, args []string) error {
+	body := client.ClientCheckRequest{User: args[0], Relation: args[1], Object: args[2]}
+
+	response, err := session.fgaClient.Check(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("check failed: %w", err)
+	}
+
+	return output.Display(*response) //nolint:wrapcheck
+}
+
+func (session *session) listObjects(ctx context.Context, args []string) error {
+	body := client.ClientListObjectsRequest{User: args[0], Relation: args[1], Type: args[2]}
+
+	response, err := session.fgaClient.ListObjects(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to list objects due to %w", err)
+	}
+
+	return output.Display(*response) //nolint:wrapcheck
+}
+
+func (session *session) listUsers(ctx context.Context, args []string) error {
+	objectType, objectID, found := strings.Cut(args[0], ":")
+	if !found {
+		return clierrors.ValidationError("list-users", "object must be in the format <type>:<id>") //nolint:wrapcheck
+	}
+
+	body := client.ClientListUsersRequest{
+		Object:      openfga.FgaObject{Type: objectType, Id: objectID},
+		Relation:    args[1],
+		UserFilters: []openfga.UserTypeFilter{},
+	}
+
+	if len(args) > 2 { //nolint:mnd
+		filterType, filterRelation, hasRelation := strings.Cut(args[2], "#")
+
+		userFilter := openfga.UserTypeFilter{Type: filterType}
+		if hasRelation {
+			userFilter.Relation = &filterRelation
+		}
+
+		body.UserFilters = append(body.UserFilters, userFilter)
+	}
+
+	response, err := session.fgaClient.ListUsers(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to list users due to %w", err)
+	}
+
+	return output.Display(*response) //nolint:wrapcheck
+}
+
+func (session *session) listRelations(ctx context.Context, args []string) error {
+	relations := args[2:]
+	if len(relations) == 0 {
+		relations = session.relations[objectType(args[1])]
+	}
+
+	if len(relations) == 0 {
+		return output.Display(client.ClientListRelationsResponse{Relations: []string{}}) //nolint:wrapcheck
+	}
+
+	body := client.ClientListRelationsRequest{User: args[0], Object: args[1], Relations: relations}
+
+	response, err := session.fgaClient.ListRelations(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to list relations due to %w", err)
+	}
+
+	if response.Relations == nil {
+		response.Relations = []string{}
+	}
+
+	return output.Display(*response) //nolint:wrapcheck
+}
+
+func (session *session) expand(ctx context.Context, args []string) error {
+	body := client.ClientExpandRequest{Relation: args[0], Object: args[1]}
+
+	response, err := session.fgaClient.Expand(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to expand tuples due to %w", err)
+	}
+
+	return output.Display(*response) //nolint:wrapcheck
+}
+
+func (session *session) write(ctx context.Context, args []string) error {
+	body := client.ClientWriteTuplesBody{
+		client.ClientTupleKey{User: args[0], Relation: args[1], Object: args[2]},
+	}
+
+	_, err := session.fgaClient.WriteTuples(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	return output.Display(map[string]client.ClientWriteTuplesBody{"successful": body}) //nolint:wrapcheck
+}
+
+func (session *session) delete(ctx context.Context, args []string) error {
+	body := client.ClientDeleteTuplesBody{
+		client.ClientTupleKeyWithoutCondition{User: args[0], Relation: args[1], Object: args[2]},
+	}
+
+	_, err := session.fgaClient.DeleteTuples(ctx).Body(body).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to delete tuples due to %w", err)
+	}
+
+	return output.Display(output.EmptyStruct{}) //nolint:wrapcheck
+}
+
+func (session *session) showHistory(context.Context, []string) error {
+	for index, line := range session.editor.History() {
+		fmt.Fprintf(session.output, "%5d  %s\n", index+1, line)
+	}
+
+	return nil
+}
+
+func (session *session) help(context.Context, []string) error {
+	for _, command := range shellCommands {
+		fmt.Fprintf(session.output, "  %-60s %s\n", command.usage, command.description)
+	}
+
+	return nil
+}
+
+func objectType(object string) string {
+	objectType, _, _ := strings.Cut(object, ":")
+
+	return objectType
+}
+
+func (session *session) types() []string {
+	types := make([]string, 0, len(session.relations))
+	for typeName := range session.relations {
+		types = append(types, typeName)
+	}
+
+	slices.Sort(types)
+
+	return types
+}
+
+// allRelations returns the relations of every type of the model.
+func (session *session) allRelations() []string {
+	relations := []string{}
+
+	for _, typeName := range session.types() {
+		for _, relation := range session.relations[typeName] {
+			if !slices.Contains(relations, relation) {
+				relations = append(relations, relation)
+			}
+		}
+	}
+
+	slices.Sort(relations)
+
+	return relations
+}
+
+// complete completes command names, and the arguments of commands with the types and relations
+// of the model. Once an argument holds an object, relations are completed from its type.
+func (session *session) complete(previousWords []string, word string) []string {
+	if len(previousWords) == 0 {
+		names := []string{}
+		for _, command := range shellCommands {
+			names = append(names, command.name)
+		}
+
+		return names
+	}
+
+	command := findShellCommand(previousWords[0])
+	if command == nil || len(command.arguments) == 0 {
+		return nil
+	}
+
+	args := previousWords[1:]
+	position := min(len(args), len(command.arguments)-1)
+
+	if len(args) >= len(command.arguments) && !command.variadic {
+		return nil
+	}
+
+	switch command.arguments[position] {
+	case argumentType:
+		return session.types()
+	case argumentUserFilter:
+		return session.completeUser(word, "")
+	case argumentUser:
+		return session.completeUser(word, ":")
+	case argumentObject:
+		return session.typePrefixes(":")
+	case argumentRelation:
+		for index, kind := range command.arguments[:min(len(args), len(command.arguments))] {
+			if kind == argumentObject {
+				return session.relations[objectType(args[index])]
+			}
+		}
+
+		return session.allRelations()
+	}
+
+	return nil
+}
+
+// completeUser completes the type of a user, followed by typeSuffix, then, for a userset, its relation.
+func (session *session) completeUser(word string, typeSuffix string) []string {
+	user, _, isUserset := strings.Cut(word, "#")
+	if !isUserset {
+		return session.typePrefixes(typeSuffix)
+	}
+
+	candidates := []string{}
+	for _, relation := range session.relations[objectType(user)] {
+		candidates = append(candidates, user+"#"+relation)
+	}
+
+	return candidates
+}
+
+func (session *session) typePrefixes(suffix string) []string {
+	candidates := []string{}
+	for _, typeName := range session.types() {
+		candidates = append(candidates, typeName+suffix)
+	}
+
+	return candidates
+}
@@ -0,0 +1,164 @@
+package shell
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/lineeditor"
+	mock_client "github.com/openfga/cli/internal/mocks"
+)
+
+var errMockListRelations = errors.New("mock error")
+
+func shellTestModel() *openfga.AuthorizationModel {
+	return &openfga.AuthorizationModel{
+		SchemaVersion: "1.1",
+		TypeDefinitions: []openfga.TypeDefinition{
+			{Type: "user"},
+			{Type: "group", Relations: &map[string]openfga.Userset{"member": {}}},
+			{Type: "document", Relations: &map[string]openfga.Userset{"viewer": {}, "owner": {}}},
+		},
+	}
+}
+
+func TestSessionComplete(t *testing.T) {
+	t.Parallel()
+
+	shellSession := newSession(nil, shellTestModel(), io.Discard)
+
+	tests := []struct {
+		name          string
+		previousWords []string
+		word          string
+		expected      []string
+	}{
+		{
+			name:     "command names",
+			expected: []string{"check", "list-objects", "list-users", "list-relations", "expand", "write", "delete", "history", "help", "exit"}, //nolint:lll
+		},
+		{
+			name:          "user type",
+			previousWords: []string{"check"},
+			expected:      []string{"document:", "group:", "user:"},
+		},
+		{
+			name:          "userset relation",
+			previousWords: []string{"check"},
+			word:          "group:eng#",
+			expected:      []string{"group:eng#member"},
+		},
+		{
+			name:          "relations of every type before the object",
+			previousWords: []string{"check", "user:anne"},
+			expected:      []string{"member", "owner", "viewer"},
+		},
+		{
+			name:          "object type",
+			previousWords: []string{"check", "user:anne", "viewer"},
+			expected:      []string{"document:", "group:", "user:"},
+		},
+		{
+			name:          "relations of the object type",
+			previousWords: []string{"list-relations", "user:anne", "document:1", "owner"},
+			expected:      []string{"owner", "viewer"},
+		},
+		{
+			name:          "type",
+			previousWords: []string{"list-objects", "user:anne", "viewer"},
+			expected:      []string{"document", "group", "user"},
+		},
+		{
+			name:          "user filter type",
+			previousWords: []string{"list-users", "document:1", "viewer"},
+			expected:      []string{"document", "group", "user"},
+		},
+		{
+			name:          "too many arguments",
+			previousWords: []string{"expand", "viewer", "document:1"},
+		},
+		{
+			name:          "unknown command",
+			previousWords: []string{"unknown"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, test.expected, shellSession.complete(test.previousWords, test.word))
+		})
+	}
+}
+
+func TestSessionExecuteValidatesCommands(t *testing.T) {
+	t.Parallel()
+
+	shellSession := newSession(nil, nil, io.Discard)
+
+	err := shellSession.execute(t.Context(), "unknown user:anne")
+	require.ErrorIs(t, err, clierrors.ErrValidation)
+	assert.Contains(t, err.Error(), `unknown command "unknown"`)
+
+	err = shellSession.execute(t.Context(), "check user:anne viewer")
+	require.ErrorIs(t, err, clierrors.ErrValidation)
+	assert.Contains(t, err.Error(), "usage: check <user> <relation> <object>")
+
+	err = shellSession.execute(t.Context(), "expand viewer document:1 extra")
+	require.ErrorIs(t, err, clierrors.ErrValidation)
+
+	require.NoError(t, shellSession.execute(t.Context(), "   "))
+	require.NoError(t, shellSession.execute(t.Context(), "# a comment"))
+	require.ErrorIs(t, shellSession.execute(t.Context(), "quit"), errExit)
+}
+
+func TestSessionListRelationsOfModelType(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	mockFgaClient := mock_client.NewMockSdkClient(mockCtrl)
+
+	mockExecute := mock_client.NewMockSdkClientListRelationsRequestInterface(mockCtrl)
+	mockExecute.EXPECT().Execute().Return(nil, errMockListRelations)
+
+	mockBody := mock_client.NewMockSdkClientListRelationsRequestInterface(mockCtrl)
+	mockBody.EXPECT().Body(client.ClientListRelationsRequest{
+		User:      "user:anne",
+		Object:    "document:1",
+		Relations: []string{"owner", "viewer"},
+	}).Return(mockExecute)
+
+	mockFgaClient.EXPECT().ListRelations(t.Context()).Return(mockBody)
+
+	shellSession := newSession(mockFgaClient, shellTestModel(), io.Discard)
+
+	err := shellSession.execute(t.Context(), "list-relations user:anne document:1")
+	require.ErrorIs(t, err, errMockListRelations)
+}
+
+func TestSessionRun(t *testing.T) {
+	t.Parallel()
+
+	output := &strings.Builder{}
+	shellSession := newSession(nil, nil, output)
+	shellSession.editor = lineeditor.NewReader(
+		strings.NewReader("help\nbogus\nhistory\nexit\ncheck user:anne viewer document:1\n"), io.Discard)
+
+	require.NoError(t, shellSession.run(t.Context()))
+
+	assert.Contains(t, output.String(), "check <user> <relation> <object>")
+	assert.Contains(t, output.String(), `Error: validation error - shell: unknown command "bogus"`)
+	assert.Contains(t, output.String(), "    3  history\n")
+	assert.NotContains(t, output.String(), "    4")
+}
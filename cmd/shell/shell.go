@@ -0,0 +1,162 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package shell contains the interactive shell command.
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/lineeditor"
+	"github.com/openfga/cli/internal/localserver"
+)
+
+const defaultHistoryFileName = ".fga_shell_history"
+
+// readShellModel reads the model the shell completes types and relations from. A store without a
+// model is not an error, there is just nothing to complete.
+func readShellModel(
+	ctx context.Context,
+	clientConfig fga.ClientConfig,
+	fgaClient client.SdkClient,
+) (*openfga.AuthorizationModel, error) {
+	response, err := authorizationmodel.ReadFromStore(ctx, clientConfig, fgaClient)
+	if errors.Is(err, clierrors.ErrAuthorizationModelNotFound) {
+		return nil, nil //nolint:nilnil
+	}
+
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return response.AuthorizationModel, nil
+}
+
+func historyFileName(cmd *cobra.Command) string {
+	if cmd.Flags().Changed("history-file") {
+		fileName, _ := cmd.Flags().GetString("history-file")
+
+		return fileName
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(homeDir, defaultHistoryFileName)
+}
+
+// ShellCmd represents the shell command.
+var ShellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Interactive shell",
+	Long: `Run queries and write tuples from an interactive shell that keeps one connection to the store.
+
+Commands are read one per line: check, list-objects, list-users, list-relations, expand, write and delete take the same arguments as their "fga query" and "fga tuple" counterparts. Run "help" for the list of commands.
+
+Types and relations of the model are completed with Tab, and the commands of previous sessions are kept in the history file.
+
+With --file, the store file's model and tuples are loaded into an embedded server, and the shell runs against it; changes are lost when the shell exits.`, //nolint:lll
+	Example: `fga shell --store-id=01H0H015178Y2V4CX10C2KGHF4
+fga shell --file store.fga.yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		clientConfig := cmdutils.GetClientConfig(cmd)
+		fileName, _ := cmd.Flags().GetString("file")
+
+		if fileName != "" {
+			allowExternalFiles, _ := cmd.Flags().GetBool("allow-external-files")
+			maxTypes, _ := cmd.Flags().GetInt("max-types-per-authorization-model")
+
+			localServer, _, localClientConfig, err := localserver.StartWithStoreFile(cmd.Context(), localserver.Config{
+				Addr:                          localserver.DefaultAddr,
+				MaxTypesPerAuthorizationModel: maxTypes,
+			}, fileName, allowExternalFiles)
+			if err != nil {
+				return err
+			}
+			defer localServer.Close()
+
+			clientConfig = localClientConfig
+
+			fmt.Fprintf(os.Stderr, "Loaded %s into a local store (%s)\n", fileName, clientConfig.StoreID)
+		} else if clientConfig.StoreID == "" {
+			return clierrors.ValidationError("shell", "either --store-id or --file is required") //nolint:wrapcheck
+		}
+
+		fgaClient, err := clientConfig.GetFgaClient()
+		if err != nil {
+			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+		}
+
+		model, err := readShellModel(cmd.Context(), clientConfig, fgaClient)
+		if err != nil {
+			return err
+		}
+
+		if model == nil {
+			fmt.Fprintln(os.Stderr, "The store has no model, types and relations will not be completed")
+		}
+
+		shellSession := newSession(fgaClient, model, os.Stdout)
+		shellSession.editor = lineeditor.New(os.Stdin, os.Stdout, shellSession.complete)
+
+		historyFile := historyFileName(cmd)
+		if historyFile != "" {
+			if err := shellSession.editor.LoadHistory(historyFile); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+
+		if shellSession.editor.Interactive() {
+			fmt.Fprintln(os.Stderr, `Type "help" for the list of commands, "exit" or Ctrl+D to leave`)
+		}
+
+		runErr := shellSession.run(cmd.Context())
+
+		if historyFile != "" && shellSession.editor.Interactive() {
+			if err := shellSession.editor.SaveHistory(historyFile); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}
+
+		return runErr
+	},
+}
+
+func init() {
+	ShellCmd.Flags().String("store-id", "", "Store ID")
+	ShellCmd.Flags().String("model-id", "", "Model ID")
+	ShellCmd.Flags().String("file", "", "Store file to load into an embedded server, instead of connecting to the store of --store-id")
+	ShellCmd.Flags().String("history-file", "", "File to keep the command history in (defaults to ~/"+defaultHistoryFileName+", set to \"\" to disable)") //nolint:lll
+
+	ShellCmd.Flags().Int("max-types-per-authorization-model", localserver.DefaultMaxTypesPerAuthorizationModel,
+		"Maximum number of types allowed in the model loaded from --file")
+	ShellCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
+}
@@ -0,0 +1,302 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
+	"github.com/openfga/cli/internal/tuple"
+)
+
+// tupleSource calls handle with the tuples of a store or a store file, one page at a time.
+type tupleSource func(handle func(tuples []client.ClientTupleKey) error) error
+
+// storeTupleSource reads every tuple in the store the client is configured for.
+func storeTupleSource(ctx context.Context, fgaClient client.SdkClient) tupleSource {
+	return func(handle func(tuples []client.ClientTupleKey) error) error {
+		return tuple.ReadPages(ctx, fgaClient, &client.ClientReadRequest{}, 0, tuple.MaxReadPageSize, nil, "",
+			func(tuples []openfga.Tuple, _ string) error {
+				keys := make([]client.ClientTupleKey, 0, len(tuples))
+				for _, t := range tuples {
+					keys = append(keys, t.GetKey())
+				}
+
+				return handle(keys)
+			})
+	}
+}
+
+func fileTupleSource(tuples []client.ClientTupleKey) tupleSource {
+	return func(handle func(tuples []client.ClientTupleKey) error) error {
+		return handle(tuples)
+	}
+}
+
+// tupleDiff holds the tuples only found in the target (added) or only in the source (removed).
+// A tuple whose condition differs between the two is both removed and added.
+type tupleDiff struct {
+	AddedCount   int                     `json:"added_count"`
+	RemovedCount int                     `json:"removed_count"`
+	Added        []client.ClientTupleKey `json:"added"`
+	Removed      []client.ClientTupleKey `json:"removed"`
+}
+
+type storeDiffResponse struct {
+	Model  *authorizationmodel.ModelDiff `json:"model"`
+	Tuples *tupleDiff                    `json:"tuples"`
+}
+
+func tupleIdentity(key client.ClientTupleKey) (string, error) {
+	var conditionName string
+
+	var conditionContext map[string]any
+
+	if key.Condition != nil {
+		conditionName = key.Condition.Name
+
+		if key.Condition.Context != nil && len(*key.Condition.Context) > 0 {
+			conditionContext = *key.Condition.Context
+		}
+	}
+
+	identity, err := json.Marshal([]any{key.User, key.Relation, key.Object, conditionName, conditionContext})
+	if err != nil {
+		return "", fmt.Errorf("failed to compare tuple due to %w", err)
+	}
+
+	return string(identity), nil
+}
+
+// diffTuples keeps every tuple of from in memory, indexed by identity, then matches the tuples
+// of to against that index page by page. The tuples of to are only kept when they are added, but
+// a store file source already holds all of its tuples, as they are read along with the file.
+func diffTuples(from tupleSource, to tupleSource) (*tupleDiff, error) {
+	fromTuples := []client.ClientTupleKey{}
+	fromIndex := map[string]int{}
+
+	err := from(func(tuples []client.ClientTupleKey) error {
+		for _, key := range tuples {
+			identity, err := tupleIdentity(key)
+			if err != nil {
+				return err
+			}
+
+			fromIndex[identity] = len(fromTuples)
+			fromTuples = append(fromTuples, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &tupleDiff{Added: []client.ClientTupleKey{}, Removed: []client.ClientTupleKey{}}
+	matched := make([]bool, len(fromTuples))
+
+	err = to(func(tuples []client.ClientTupleKey) error {
+		for _, key := range tuples {
+			identity, err := tupleIdentity(key)
+			if err != nil {
+				return err
+			}
+
+			if index, ok := fromIndex[identity]; ok {
+				matched[index] = true
+
+				continue
+			}
+
+			diff.Added = append(diff.Added, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for index, key := range fromTuples {
+		if !matched[index] {
+			diff.Removed = append(diff.Removed, key)
+		}
+	}
+
+	diff.AddedCount = len(diff.Added)
+	diff.RemovedCount = len(diff.Removed)
+
+	return diff, nil
+}
+
+// readStoreModel reads the model of the store, or returns an empty model if the store has none.
+func readStoreModel(
+	ctx context.Context, clientConfig fga.ClientConfig, fgaClient client.SdkClient,
+) (*authorizationmodel.AuthzModel, error) {
+	authModel := &authorizationmodel.AuthzModel{}
+
+	response, err := authorizationmodel.ReadFromStore(ctx, clientConfig, fgaClient)
+	if err != nil {
+		if errors.Is(err, clierrors.ErrAuthorizationModelNotFound) {
+			return authModel, nil
+		}
+
+		return nil, err //nolint:wrapcheck
+	}
+
+	authModel.Set(*response.AuthorizationModel)
+
+	return authModel, nil
+}
+
+func readFileModel(
+	storeData *storetest.StoreData, format authorizationmodel.ModelFormat,
+) (*authorizationmodel.AuthzModel, error) {
+	authModel := &authorizationmodel.AuthzModel{}
+
+	if err := authModel.ReadModelFromStringContained(storeData.Model, format, storeData.ModelContainBase()); err != nil {
+		return nil, fmt.Errorf("failed to read model: %w", err)
+	}
+
+	return authModel, nil
+}
+
+func diffStores(
+	fromModel *authorizationmodel.AuthzModel, fromTuples tupleSource,
+	toModel *authorizationmodel.AuthzModel, toTuples tupleSource,
+) (*storeDiffResponse, error) {
+	modelDiff, err := authorizationmodel.Diff(fromModel, toModel)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare models due to %w", err)
+	}
+
+	tuplesDiff, err := diffTuples(fromTuples, toTuples)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare tuples due to %w", err)
+	}
+
+	return &storeDiffResponse{Model: modelDiff, Tuples: tuplesDiff}, nil
+}
+
+// readDiffTarget reads the model and tuples to compare the store against, either from
+// the --target-store-id store or from the --file store file.
+func readDiffTarget(
+	cmd *cobra.Command, clientConfig fga.ClientConfig,
+) (*authorizationmodel.AuthzModel, tupleSource, error) {
+	fileName, _ := cmd.Flags().GetString("file")
+	if fileName != "" {
+		allowExternalFiles, _ := cmd.Flags().GetBool("allow-external-files")
+
+		format, storeData, err := storetest.ReadFromFile(fileName, "", allowExternalFiles)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read from file: %w", err)
+		}
+
+		model, err := readFileModel(storeData, format)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return model, fileTupleSource(storeData.Tuples), nil
+	}
+
+	targetConfig := clientConfig
+	targetConfig.StoreID, _ = cmd.Flags().GetString("target-store-id")
+	targetConfig.AuthorizationModelID, _ = cmd.Flags().GetString("target-model-id")
+
+	targetClient, err := targetConfig.GetFgaClient()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to initialize FGA Client due to %w", err)
+	}
+
+	model, err := readStoreModel(cmd.Context(), targetConfig, targetClient)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return model, storeTupleSource(cmd.Context(), targetClient), nil
+}
+
+// diffCmd represents the store diff command.
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two stores",
+	Long: `Compare the authorization model and the tuples of a store with those of another store,
+or of a store file such as one written by fga store export.
+
+Types, relations and conditions are compared between the models (the latest, unless a model ID is
+given). Tuples found only in the target are reported as added, and those found only in --store-id
+as removed.`,
+	Example: `fga store diff --store-id=01H0H015178Y2V4CX10C2KGHF4 --target-store-id=01H0H015178Y2V4CX10C2KGHF5
+fga store diff --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=store.fga.yaml`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		clientConfig := cmdutils.GetClientConfig(cmd)
+
+		fgaClient, err := clientConfig.GetFgaClient()
+		if err != nil {
+			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+		}
+
+		fromModel, err := readStoreModel(cmd.Context(), clientConfig, fgaClient)
+		if err != nil {
+			return fmt.Errorf("failed to diff stores: %w", err)
+		}
+
+		toModel, toTuples, err := readDiffTarget(cmd, clientConfig)
+		if err != nil {
+			return fmt.Errorf("failed to diff stores: %w", err)
+		}
+
+		response, err := diffStores(fromModel, storeTupleSource(cmd.Context(), fgaClient), toModel, toTuples)
+		if err != nil {
+			return fmt.Errorf("failed to diff stores: %w", err)
+		}
+
+		return output.Display(response) //nolint:wrapcheck
+	},
+}
+
+func init() {
+	diffCmd.Flags().String("store-id", "", "Store ID")
+	diffCmd.Flags().String("model-id", "", "Authorization Model ID (defaults to the latest model of the store)")
+	diffCmd.Flags().String("target-store-id", "", "ID of the store to compare against")
+	diffCmd.Flags().String("target-model-id", "", "Authorization Model ID of the target store (defaults to its latest model)")
+	diffCmd.Flags().String("file", "", "Store file to compare against, instead of a target store")
+	diffCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
+
+	diffCmd.MarkFlagsMutuallyExclusive("target-store-id", "file")
+	diffCmd.MarkFlagsOneRequired("target-store-id", "file")
+
+	err := diffCmd.MarkFlagRequired("store-id")
+	if err != nil {
+		fmt.Print(err)
+		os.Exit(1)
+	}
+}
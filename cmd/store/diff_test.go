@@ -0,0 +1,63 @@
+package store
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclient "github.com/openfga/cli/internal/mocks"
+)
+
+func TestDiffTuples(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	inRange := &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{"x": "1"}}
+
+	expectReadPage(mockCtrl, mockFgaClient, "", &client.ClientReadResponse{
+		Tuples: []openfga.Tuple{
+			{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+			{Key: openfga.TupleKey{User: "user:beth", Relation: "viewer", Object: "document:1"}},
+		},
+		ContinuationToken: "page-2",
+	}, nil)
+	expectReadPage(mockCtrl, mockFgaClient, "page-2", &client.ClientReadResponse{
+		Tuples: []openfga.Tuple{
+			{Key: openfga.TupleKey{User: "user:carl", Relation: "viewer", Object: "document:1", Condition: inRange}},
+			{Key: openfga.TupleKey{
+				User: "user:dave", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "in_range"},
+			}},
+		},
+	}, nil)
+
+	target := []client.ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{
+			User: "user:carl", Relation: "viewer", Object: "document:1",
+			Condition: &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{"x": "2"}},
+		},
+		{
+			User: "user:dave", Relation: "viewer", Object: "document:1",
+			Condition: &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{}},
+		},
+		{User: "user:erin", Relation: "editor", Object: "document:1"},
+	}
+
+	diff, err := diffTuples(storeTupleSource(t.Context(), mockFgaClient), fileTupleSource(target))
+	require.NoError(t, err)
+
+	assert.Equal(t, []client.ClientTupleKey{target[1], target[3]}, diff.Added)
+	assert.Equal(t, []client.ClientTupleKey{
+		{User: "user:beth", Relation: "viewer", Object: "document:1"},
+		{User: "user:carl", Relation: "viewer", Object: "document:1", Condition: inRange},
+	}, diff.Removed)
+	assert.Equal(t, 2, diff.AddedCount)
+	assert.Equal(t, 2, diff.RemovedCount)
+}
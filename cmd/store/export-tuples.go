@@ -0,0 +1,244 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/tuple"
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+var (
+	errNoExportCheckpoint       = errors.New("no export checkpoint found")
+	errExportCheckpointMismatch = errors.New("export checkpoint was created for a different store")
+)
+
+// exportCheckpoint records how far a streamed tuple export got, so that it can be
+// resumed from the last page written instead of starting over.
+type exportCheckpoint struct {
+	StoreID           string `json:"store_id"`
+	TupleFile         string `json:"tuple_file"`
+	ContinuationToken string `json:"continuation_token"`
+	TuplesWritten     int64  `json:"tuples_written"`
+	BytesWritten      int64  `json:"bytes_written"`
+	Complete          bool   `json:"complete"`
+}
+
+func exportCheckpointFileName(tupleFile string) string {
+	return tupleFile + ".checkpoint"
+}
+
+func readExportCheckpoint(fileName string) (*exportCheckpoint, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w at %s", errNoExportCheckpoint, fileName)
+		}
+
+		return nil, fmt.Errorf("failed to read export checkpoint due to %w", err)
+	}
+
+	checkpoint := &exportCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse export checkpoint %s due to %w", fileName, err)
+	}
+
+	return checkpoint, nil
+}
+
+// save writes the checkpoint to a temporary file and renames it into place, so an
+// interrupted export never leaves a partially written checkpoint behind.
+func (checkpoint *exportCheckpoint) save(fileName string) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal export checkpoint due to %w", err)
+	}
+
+	tmpFileName := fileName + ".tmp"
+	if err := os.WriteFile(tmpFileName, data, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write export checkpoint due to %w", err)
+	}
+
+	if err := os.Rename(tmpFileName, fileName); err != nil {
+		return fmt.Errorf("failed to write export checkpoint due to %w", err)
+	}
+
+	return nil
+}
+
+// openTupleExportFile opens the tuple file for writing. When resuming, the file is
+// truncated to the size recorded in the checkpoint, dropping anything written after
+// the last completed page, and positioned at its end.
+func openTupleExportFile(tupleFile string, checkpoint *exportCheckpoint) (*os.File, error) {
+	if checkpoint.BytesWritten == 0 {
+		file, err := os.OpenFile(tupleFile, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:mnd
+		if err != nil {
+			return nil, fmt.Errorf("failed to open tuple file due to %w", err)
+		}
+
+		return file, nil
+	}
+
+	file, err := os.OpenFile(tupleFile, os.O_WRONLY, 0o600) //nolint:mnd
+	if err != nil {
+		return nil, fmt.Errorf("failed to open tuple file to resume export due to %w", err)
+	}
+
+	if err := file.Truncate(checkpoint.BytesWritten); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("failed to truncate tuple file to resume export due to %w", err)
+	}
+
+	if _, err := file.Seek(checkpoint.BytesWritten, io.SeekStart); err != nil {
+		_ = file.Close()
+
+		return nil, fmt.Errorf("failed to seek tuple file to resume export due to %w", err)
+	}
+
+	return file, nil
+}
+
+// exportTuples streams every tuple in the store to tupleFile one page at a time,
+// checkpointing after each page. With resume set, it continues from the checkpoint
+// left by an interrupted export. It returns the total number of tuples in the file.
+func exportTuples(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	storeID string,
+	tupleFile string,
+	resume bool,
+) (int64, error) {
+	checkpointFile := exportCheckpointFileName(tupleFile)
+	checkpoint := &exportCheckpoint{StoreID: storeID, TupleFile: tupleFile}
+
+	if resume {
+		var err error
+
+		checkpoint, err = readExportCheckpoint(checkpointFile)
+		if err != nil {
+			return 0, err
+		}
+
+		if checkpoint.StoreID != storeID {
+			return 0, fmt.Errorf("%w: checkpoint is for store %s, not %s",
+				errExportCheckpointMismatch, checkpoint.StoreID, storeID)
+		}
+	}
+
+	if !checkpoint.Complete {
+		if err := streamTuplesToFile(ctx, fgaClient, tupleFile, checkpointFile, checkpoint); err != nil {
+			return checkpoint.TuplesWritten, err
+		}
+	}
+
+	if err := os.Remove(checkpointFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return checkpoint.TuplesWritten, fmt.Errorf("failed to remove export checkpoint due to %w", err)
+	}
+
+	return checkpoint.TuplesWritten, nil
+}
+
+func streamTuplesToFile(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	tupleFile string,
+	checkpointFile string,
+	checkpoint *exportCheckpoint,
+) error {
+	file, err := openTupleExportFile(tupleFile, checkpoint)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	writer, err := tuplefile.NewWriter(tupleFile, file, checkpoint.BytesWritten == 0)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	err = tuple.ReadPages(ctx, fgaClient, &client.ClientReadRequest{}, 0, tuple.MaxReadPageSize, nil,
+		checkpoint.ContinuationToken, func(tuples []openfga.Tuple, continuationToken string) error {
+			for _, t := range tuples {
+				if err := writer.Write(t.GetKey()); err != nil {
+					return err //nolint:wrapcheck
+				}
+			}
+
+			if err := writer.Flush(); err != nil {
+				return fmt.Errorf("failed to write tuple file due to %w", err)
+			}
+
+			offset, err := file.Seek(0, io.SeekCurrent)
+			if err != nil {
+				return fmt.Errorf("failed to write tuple file due to %w", err)
+			}
+
+			checkpoint.ContinuationToken = continuationToken
+			checkpoint.TuplesWritten += int64(len(tuples))
+			checkpoint.BytesWritten = offset
+			checkpoint.Complete = continuationToken == ""
+
+			return checkpoint.save(checkpointFile)
+		})
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close tuple file due to %w", err)
+	}
+
+	return nil
+}
+
+// tupleFileReference returns the path of tupleFile as it should be referenced from the
+// store file, relative to the directory of outputFile (or the working directory when
+// the store is written to stdout) so that fga store import can locate it.
+func tupleFileReference(outputFile string, tupleFile string) string {
+	baseDir := "."
+	if outputFile != "" {
+		baseDir = filepath.Dir(outputFile)
+	}
+
+	absBaseDir, err := filepath.Abs(baseDir)
+	if err != nil {
+		return tupleFile
+	}
+
+	absTupleFile, err := filepath.Abs(tupleFile)
+	if err != nil {
+		return tupleFile
+	}
+
+	relative, err := filepath.Rel(absBaseDir, absTupleFile)
+	if err != nil {
+		return absTupleFile
+	}
+
+	return filepath.ToSlash(relative)
+}
@@ -0,0 +1,117 @@
+package store
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclient "github.com/openfga/cli/internal/mocks"
+	"github.com/openfga/cli/internal/tuple"
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+var errReadFailed = errors.New("read failed")
+
+func expectReadPage(
+	mockCtrl *gomock.Controller,
+	mockFgaClient *mockclient.MockSdkClient,
+	continuationToken string,
+	response *client.ClientReadResponse,
+	err error,
+) {
+	mockReadRequest := mockclient.NewMockSdkClientReadRequestInterface(mockCtrl)
+	mockReadRequest.EXPECT().Body(client.ClientReadRequest{}).Return(mockReadRequest)
+	mockReadRequest.EXPECT().Options(client.ClientReadOptions{
+		PageSize:          openfga.PtrInt32(tuple.MaxReadPageSize),
+		ContinuationToken: openfga.PtrString(continuationToken),
+	}).Return(mockReadRequest)
+	mockReadRequest.EXPECT().Execute().Return(response, err)
+	mockFgaClient.EXPECT().Read(gomock.Any()).Return(mockReadRequest)
+}
+
+func TestExportTuplesResume(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	firstPage := []openfga.Tuple{
+		{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+		{Key: openfga.TupleKey{User: "user:beth", Relation: "viewer", Object: "document:1"}},
+	}
+	secondPage := []openfga.Tuple{
+		{Key: openfga.TupleKey{User: "group:eng#member", Relation: "editor", Object: "document:2"}},
+	}
+
+	tupleFile := filepath.Join(t.TempDir(), "tuples.csv")
+	checkpointFile := exportCheckpointFileName(tupleFile)
+
+	expectReadPage(mockCtrl, mockFgaClient, "", &client.ClientReadResponse{
+		Tuples: firstPage, ContinuationToken: "page-2",
+	}, nil)
+	expectReadPage(mockCtrl, mockFgaClient, "page-2", nil, errReadFailed)
+
+	written, err := exportTuples(t.Context(), mockFgaClient, "store-1", tupleFile, false)
+	require.ErrorIs(t, err, errReadFailed)
+	assert.Equal(t, int64(2), written)
+
+	checkpoint, err := readExportCheckpoint(checkpointFile)
+	require.NoError(t, err)
+	assert.Equal(t, "page-2", checkpoint.ContinuationToken)
+	assert.False(t, checkpoint.Complete)
+
+	// resuming for another store is refused
+	_, err = exportTuples(t.Context(), mockFgaClient, "store-2", tupleFile, true)
+	require.ErrorIs(t, err, errExportCheckpointMismatch)
+
+	// anything written after the last checkpoint is discarded on resume
+	file, err := os.OpenFile(tupleFile, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString("user,partial")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	expectReadPage(mockCtrl, mockFgaClient, "page-2", &client.ClientReadResponse{Tuples: secondPage}, nil)
+
+	written, err = exportTuples(t.Context(), mockFgaClient, "store-1", tupleFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), written)
+
+	_, err = os.Stat(checkpointFile)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	tuples, err := tuplefile.ReadTupleFile(tupleFile)
+	require.NoError(t, err)
+
+	expected := make([]client.ClientTupleKey, 0, len(firstPage)+len(secondPage))
+	for _, readTuple := range append(firstPage, secondPage...) {
+		expected = append(expected, readTuple.GetKey())
+	}
+
+	assert.Equal(t, expected, tuples)
+}
+
+func TestExportTuplesResumeWithoutCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	_, err := exportTuples(t.Context(), mockFgaClient, "store-1", filepath.Join(t.TempDir(), "tuples.jsonl"), true)
+	require.ErrorIs(t, err, errNoExportCheckpoint)
+}
+
+func TestTupleFileReference(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "tuples.jsonl", tupleFileReference("export/store.fga.yaml", "export/tuples.jsonl"))
+	assert.Equal(t, "../tuples.jsonl", tupleFileReference("export/store.fga.yaml", "tuples.jsonl"))
+	assert.Equal(t, "data/tuples.csv", tupleFileReference("", "data/tuples.csv"))
+}
@@ -18,6 +18,7 @@ package store
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
@@ -33,6 +34,7 @@ import (
 	"github.com/openfga/cli/internal/output"
 	"github.com/openfga/cli/internal/storetest"
 	"github.com/openfga/cli/internal/tuple"
+	"github.com/openfga/cli/internal/tuplefile"
 )
 
 // defaultTuplePageSize defines the default number of pages to return when calling Read.
@@ -40,6 +42,8 @@ const (
 	defaultMaxTupleCount = 100
 )
 
+var errResumeRequiresTupleFile = errors.New("--resume requires --tuple-file")
+
 // buildStoreData compiles all the data necessary to output to file or stdout,
 // or returns an error if this was not successful.
 func buildStoreData(ctx context.Context, config fga.ClientConfig, fgaClient client.SdkClient, maxTupleCount uint) (*storetest.StoreData, error) { //nolint:lll
@@ -65,20 +69,24 @@ func buildStoreData(ctx context.Context, config fga.ClientConfig, fgaClient clie
 		return nil, fmt.Errorf("unable to get model dsl: %w", err)
 	}
 
-	// get the tuples
-	maxPages := int(math.Ceil(float64(maxTupleCount) / float64(tuple.DefaultReadPageSize)))
+	// get the tuples, unless they are streamed to a tuple file instead
+	var outputTuples []client.ClientContextualTupleKey
 
-	rawTuples, err := tuple.Read(ctx, fgaClient, &client.ClientReadRequest{}, maxPages, tuple.DefaultReadPageSize, nil)
-	if err != nil {
-		return nil, fmt.Errorf("unable to read tuples: %w", err)
-	}
+	if maxTupleCount > 0 {
+		maxPages := int(math.Ceil(float64(maxTupleCount) / float64(tuple.DefaultReadPageSize)))
 
-	tuples := rawTuples.GetTuples()
-	maxTuplesInOutput := int(math.Min(float64(len(tuples)), float64(maxTupleCount)))
-	outputTuples := make([]client.ClientContextualTupleKey, 0, maxTuplesInOutput)
+		rawTuples, err := tuple.Read(ctx, fgaClient, &client.ClientReadRequest{}, maxPages, tuple.DefaultReadPageSize, nil)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read tuples: %w", err)
+		}
 
-	for _, t := range tuples[:maxTuplesInOutput] {
-		outputTuples = append(outputTuples, t.GetKey())
+		tuples := rawTuples.GetTuples()
+		maxTuplesInOutput := int(math.Min(float64(len(tuples)), float64(maxTupleCount)))
+		outputTuples = make([]client.ClientContextualTupleKey, 0, maxTuplesInOutput)
+
+		for _, t := range tuples[:maxTuplesInOutput] {
+			outputTuples = append(outputTuples, t.GetKey())
+		}
 	}
 
 	// get the assertions
@@ -131,32 +139,78 @@ func buildStoreData(ctx context.Context, config fga.ClientConfig, fgaClient clie
 
 // exportCmd represents the export store command.
 var exportCmd = &cobra.Command{
-	Use:     "export",
-	Short:   "Export store data",
-	Long:    `Export a store to YAML`,
-	Example: "fga store export",
+	Use:   "export",
+	Short: "Export store data",
+	Long: `Export a store to YAML.
+
+By default at most --max-tuples tuples are included inline in the YAML. Use --tuple-file to stream
+all the tuples in the store, page by page, into a separate .jsonl or .csv file referenced from the
+YAML. Progress is checkpointed after each page, so an interrupted export can be continued with --resume.`,
+	Example: `fga store export --store-id=01H0H015178Y2V4CX10C2KGHF4
+fga store export --store-id=01H0H015178Y2V4CX10C2KGHF4 --output-file=store.fga.yaml --tuple-file=tuples.jsonl
+fga store export --store-id=01H0H015178Y2V4CX10C2KGHF4 --output-file=store.fga.yaml --tuple-file=tuples.jsonl --resume`, //nolint:lll
 	RunE: func(cmd *cobra.Command, _ []string) error {
 		clientConfig := cmdutils.GetClientConfig(cmd)
 
+		maxTupleCount, _ := cmd.Flags().GetUint("max-tuples")
+		fileName, _ := cmd.Flags().GetString("output-file")
+		tupleFile, _ := cmd.Flags().GetString("tuple-file")
+		resume, _ := cmd.Flags().GetBool("resume")
+
+		if err := validateExportFlags(tupleFile, resume); err != nil {
+			return err
+		}
+
+		if tupleFile != "" {
+			// tuples are streamed to the tuple file rather than included in the yaml
+			maxTupleCount = 0
+
+			if !resume {
+				if _, err := os.Stat(tupleFile); err == nil {
+					confirm, err := confirmation.AskForConfirmation("Tuple file exists, overwrite?")
+					if err != nil {
+						return fmt.Errorf("prompt failed due to %w", err)
+					}
+
+					if !confirm {
+						fmt.Println("cancelled")
+
+						return output.Display(output.EmptyStruct{}) //nolint:wrapcheck
+					}
+				}
+			}
+		}
+
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
 			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
 		}
 
-		maxTupleCount, _ := cmd.Flags().GetUint("max-tuples")
-
 		storeData, err := buildStoreData(cmd.Context(), clientConfig, fgaClient, maxTupleCount)
 		if err != nil {
 			return fmt.Errorf("failed to export store: %w", err)
 		}
 
+		if tupleFile != "" {
+			tuplesWritten, err := exportTuples(cmd.Context(), fgaClient, clientConfig.StoreID, tupleFile, resume)
+			if err != nil {
+				return fmt.Errorf("failed to export tuples after writing %d, re-run with --resume to continue: %w",
+					tuplesWritten, err)
+			}
+
+			fmt.Fprintf(os.Stderr, "%d tuples written to %s\n", tuplesWritten, tupleFile)
+
+			if tuplesWritten > 0 {
+				storeData.TupleFile = tupleFileReference(fileName, tupleFile)
+			}
+		}
+
 		if storeData != nil {
 			storeYaml, err := yaml.Marshal(storeData)
 			if err != nil {
 				return fmt.Errorf("unable to marshal storedata yaml: %w", err)
 			}
 
-			fileName, _ := cmd.Flags().GetString("output-file")
 			if fileName == "" {
 				fmt.Println(string(storeYaml))
 
@@ -188,11 +242,29 @@ var exportCmd = &cobra.Command{
 	},
 }
 
+func validateExportFlags(tupleFile string, resume bool) error {
+	if resume && tupleFile == "" {
+		return errResumeRequiresTupleFile
+	}
+
+	if tupleFile != "" {
+		if err := tuplefile.ValidateStreamFormat(tupleFile); err != nil {
+			return fmt.Errorf("invalid --tuple-file: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func init() {
 	exportCmd.Flags().String("output-file", "", "name of the file to export the store to")
 	exportCmd.Flags().String("store-id", "", "store ID")
 	exportCmd.Flags().String("model-id", "", "Authorization Model ID")
 	exportCmd.Flags().Uint("max-tuples", defaultMaxTupleCount, "max number of tuples to return in the output")
+	exportCmd.Flags().String("tuple-file", "",
+		"stream all the tuples in the store to this file (.jsonl or .csv) instead of including them in the output")
+	exportCmd.Flags().Bool("resume", false,
+		"resume an interrupted export from the checkpoint saved next to the tuple file")
 
 	err := exportCmd.MarkFlagRequired("store-id")
 	if err != nil {
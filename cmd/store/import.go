@@ -32,6 +32,7 @@ import (
 
 	"github.com/openfga/cli/cmd/model"
 	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
 	"github.com/openfga/cli/internal/cmdutils"
 	"github.com/openfga/cli/internal/fga"
 	"github.com/openfga/cli/internal/output"
@@ -220,7 +221,7 @@ func importAssertions(
 
 	for _, modelTest := range modelTests {
 		if len(modelTest.Check) > 0 {
-			checkAssertions := getCheckAssertions(modelTest.Check)
+			checkAssertions := storetest.GetCheckAssertions(modelTest.Check)
 			assertions = append(assertions, checkAssertions...)
 		}
 	}
@@ -246,30 +247,6 @@ func importAssertions(
 	return nil
 }
 
-func getCheckAssertions(checkTests []storetest.ModelTestCheck) []client.ClientAssertion {
-	assertions := make([]client.ClientAssertion, 0, len(checkTests))
-
-	for _, checkTest := range checkTests {
-		users := storetest.GetEffectiveUsers(checkTest)
-		objects := storetest.GetEffectiveObjects(checkTest)
-
-		for _, user := range users {
-			for _, object := range objects {
-				for relation, expectation := range checkTest.Assertions {
-					assertions = append(assertions, client.ClientAssertion{
-						User:        user,
-						Relation:    relation,
-						Object:      object,
-						Expectation: expectation,
-					})
-				}
-			}
-		}
-	}
-
-	return assertions
-}
-
 func createProgressBar(total int) *progressbar.ProgressBar {
 	return progressbar.NewOptions(total,
 		progressbar.OptionSetWriter(os.Stderr),
@@ -292,6 +269,31 @@ func createProgressBar(total int) *progressbar.ProgressBar {
 	)
 }
 
+// validateStoreTuples validates the tuples of the store file against its model, before anything is
+// imported.
+func validateStoreTuples(storeData *storetest.StoreData, format authorizationmodel.ModelFormat) error {
+	if storeData.Model == "" || len(storeData.Tuples) == 0 {
+		return nil
+	}
+
+	authModel := authorizationmodel.AuthzModel{}
+	if err := authModel.ReadModelFromStringContained(storeData.Model, format, storeData.ModelContainBase()); err != nil {
+		return fmt.Errorf("failed to read model: %w", err)
+	}
+
+	report := tuple.Validate(&authModel, storeData.Tuples)
+	if report.InvalidCount == 0 {
+		return nil
+	}
+
+	if err := output.Display(report); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return clierrors.ValidationError("store import", //nolint:wrapcheck
+		fmt.Sprintf("%d of %d tuples are not valid for the model", report.InvalidCount, report.TotalCount))
+}
+
 // importCmd represents the get command.
 var importCmd = &cobra.Command{
 	Use:     "import",
@@ -331,6 +333,12 @@ var importCmd = &cobra.Command{
 			return fmt.Errorf("failed to read from file: %w", err)
 		}
 
+		if validate, _ := cmd.Flags().GetBool("validate"); validate {
+			if err := validateStoreTuples(storeData, format); err != nil {
+				return err
+			}
+		}
+
 		fgaClient, err := clientConfig.GetFgaClient()
 		if err != nil {
 			return fmt.Errorf("failed to initialize FGA Client: %w", err)
@@ -356,8 +364,9 @@ func init() {
 	importCmd.Flags().String("file", "", "File Name. The file should have the store")
 	importCmd.Flags().String("store-id", "", "Store ID")
 	importCmd.Flags().Int("max-tuples-per-write", tuple.MaxTuplesPerWrite, "Max tuples per write chunk.")
-	importCmd.Flags().Int("max-parallel-requests", tuple.MaxParallelRequests, "Max number of requests to issue to the server in parallel.")                                                                                                //nolint:lll
-	importCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file and tuple_files references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
+	importCmd.Flags().Int("max-parallel-requests", tuple.MaxParallelRequests, "Max number of requests to issue to the server in parallel.")                                                                                                         //nolint:lll
+	importCmd.Flags().Bool("validate", false, "Validate the tuples against the model of the store file before importing, and import nothing if any is invalid")                                                                                     //nolint:lll
+	importCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
 
 	if err := importCmd.MarkFlagRequired("file"); err != nil {
 		fmt.Printf("error setting flag as required - %v: %v\n", "cmd/models/write", err)
@@ -35,4 +35,6 @@ func init() {
 	StoreCmd.AddCommand(deleteCmd)
 	StoreCmd.AddCommand(importCmd)
 	StoreCmd.AddCommand(exportCmd)
+	StoreCmd.AddCommand(diffCmd)
+	StoreCmd.AddCommand(syncCmd)
 }
@@ -0,0 +1,290 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/cmd/model"
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/confirmation"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/storetest"
+	"github.com/openfga/cli/internal/tuple"
+)
+
+var errSyncTuplesFailed = errors.New("tuples failed to sync")
+
+// syncPlan lists the changes needed for the store to match the store file.
+type syncPlan struct {
+	Model       *authorizationmodel.ModelDiff `json:"model"`
+	WriteModel  bool                          `json:"write_model"`
+	WriteCount  int                           `json:"write_count"`
+	DeleteCount int                           `json:"delete_count"`
+	Writes      []client.ClientTupleKey       `json:"writes"`
+	Deletes     []client.ClientTupleKey       `json:"deletes"`
+
+	model *authorizationmodel.AuthzModel
+}
+
+type syncResponse struct {
+	Plan    *syncPlan             `json:"plan"`
+	DryRun  bool                  `json:"dry_run"`
+	ModelID string                `json:"model_id,omitempty"`
+	Writes  *tuple.ImportResponse `json:"writes,omitempty"`
+	Deletes *tuple.ImportResponse `json:"deletes,omitempty"`
+}
+
+// buildSyncPlan compares the model and tuples of the store with those of the store file.
+func buildSyncPlan(
+	ctx context.Context,
+	clientConfig fga.ClientConfig,
+	fgaClient client.SdkClient,
+	storeData *storetest.StoreData,
+	format authorizationmodel.ModelFormat,
+) (*syncPlan, error) {
+	storeModel, err := readStoreModel(ctx, clientConfig, fgaClient)
+	if err != nil {
+		return nil, err
+	}
+
+	fileModel, err := readFileModel(storeData, format)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := diffStores(storeModel, storeTupleSource(ctx, fgaClient), fileModel, fileTupleSource(storeData.Tuples))
+	if err != nil {
+		return nil, err
+	}
+
+	return &syncPlan{
+		Model:       response.Model,
+		WriteModel:  storeModel.GetID() == "" || response.Model.HasChanges(),
+		WriteCount:  response.Tuples.AddedCount,
+		DeleteCount: response.Tuples.RemovedCount,
+		Writes:      response.Tuples.Added,
+		Deletes:     response.Tuples.Removed,
+		model:       fileModel,
+	}, nil
+}
+
+// failedError returns an error if the server rejected any of the tuples to delete or write.
+func (response *syncResponse) failedError() error {
+	failedCount := 0
+
+	for _, result := range []*tuple.ImportResponse{response.Deletes, response.Writes} {
+		if result != nil {
+			failedCount += len(result.Failed)
+		}
+	}
+
+	if failedCount > 0 {
+		return fmt.Errorf("%w: %d tuples were not deleted or written", errSyncTuplesFailed, failedCount)
+	}
+
+	return nil
+}
+
+// applySyncPlan writes the model if it changed, then deletes and writes tuples. Deletes are
+// sent first, so that a tuple whose condition changed is removed before it is written again.
+func applySyncPlan(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	plan *syncPlan,
+	maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests int,
+) (*syncResponse, error) {
+	response := &syncResponse{Plan: plan}
+
+	if plan.WriteModel {
+		modelWriteRes, err := model.Write(ctx, fgaClient, *plan.model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write model: %w", err)
+		}
+
+		response.ModelID = modelWriteRes.AuthorizationModelId
+	}
+
+	if len(plan.Deletes) > 0 {
+		deletes := make([]client.ClientTupleKeyWithoutCondition, 0, len(plan.Deletes))
+		for _, key := range plan.Deletes {
+			deletes = append(deletes, tuple.TupleKeyToTupleKeyWithoutCondition(key))
+		}
+
+		result, err := tuple.ImportTuples(ctx, fgaClient,
+			tuple.DefaultMinRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests,
+			client.ClientWriteRequest{Deletes: deletes}, client.ClientWriteOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete tuples: %w", err)
+		}
+
+		response.Deletes = result
+	}
+
+	if len(plan.Writes) > 0 {
+		result, err := tuple.ImportTuples(ctx, fgaClient,
+			tuple.DefaultMinRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests,
+			client.ClientWriteRequest{Writes: plan.Writes}, client.ClientWriteOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to write tuples: %w", err)
+		}
+
+		response.Writes = result
+	}
+
+	return response, nil
+}
+
+// syncCmd represents the store sync command.
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync a store to a store file",
+	Long: `Make a store match a store file: the model is written if it differs from the latest model
+of the store, tuples in the file but not in the store are written, and tuples in the store but not in the
+file are deleted. Use --dry-run to only show the plan. Before deleting tuples, the plan is shown and
+confirmation is asked for, unless --force is set.`,
+	Example: `fga store sync --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=store.fga.yaml --dry-run
+fga store sync --store-id=01H0H015178Y2V4CX10C2KGHF4 --file=store.fga.yaml --force`,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		clientConfig := cmdutils.GetClientConfig(cmd)
+
+		fileName, _ := cmd.Flags().GetString("file")
+		allowExternalFiles, _ := cmd.Flags().GetBool("allow-external-files")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		maxTuplesPerWrite, err := cmd.Flags().GetInt("max-tuples-per-write")
+		if err != nil {
+			return fmt.Errorf("failed to parse max-tuples-per-write due to %w", err)
+		}
+
+		maxParallelRequests, err := cmd.Flags().GetInt("max-parallel-requests")
+		if err != nil {
+			return fmt.Errorf("failed to parse max-parallel-requests due to %w", err)
+		}
+
+		maxRPS, rampUpPeriodInSec, err := getSyncRateFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		format, storeData, err := storetest.ReadFromFile(fileName, "", allowExternalFiles)
+		if err != nil {
+			return fmt.Errorf("failed to read from file: %w", err)
+		}
+
+		fgaClient, err := clientConfig.GetFgaClient()
+		if err != nil {
+			return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+		}
+
+		plan, err := buildSyncPlan(cmd.Context(), clientConfig, fgaClient, storeData, format)
+		if err != nil {
+			return fmt.Errorf("failed to sync store: %w", err)
+		}
+
+		if dryRun {
+			return output.Display(syncResponse{Plan: plan, DryRun: true}) //nolint:wrapcheck
+		}
+
+		if plan.DeleteCount > 0 && !force {
+			// deleted tuples cannot be restored, so show what would be deleted before going ahead
+			if err := output.Display(syncResponse{Plan: plan, DryRun: true}); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			confirm, err := confirmation.AskForConfirmation(
+				fmt.Sprintf("Sync will delete %d tuples from the store, continue?", plan.DeleteCount))
+			if err != nil {
+				return fmt.Errorf("prompt failed due to %w", err)
+			}
+
+			if !confirm {
+				fmt.Println("cancelled")
+
+				return output.Display(output.EmptyStruct{}) //nolint:wrapcheck
+			}
+		}
+
+		response, err := applySyncPlan(cmd.Context(), fgaClient, plan,
+			maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests)
+		if err != nil {
+			return fmt.Errorf("failed to sync store: %w", err)
+		}
+
+		if err := output.Display(response); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return response.failedError()
+	},
+}
+
+// getSyncRateFlags returns the max requests per second and the ramp-up period of the writes. As in
+// tuple write, the ramp-up period defaults to a multiple of --max-rps.
+func getSyncRateFlags(cmd *cobra.Command) (int, int, error) {
+	maxRPS, err := cmd.Flags().GetInt("max-rps")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse max-rps due to %w", err)
+	}
+
+	rampUpPeriodInSec, err := cmd.Flags().GetInt("rampup-period-in-sec")
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to parse rampup-period-in-sec due to %w", err)
+	}
+
+	if cmd.Flags().Changed("max-rps") && maxRPS <= 0 {
+		return 0, 0, errors.New("max-rps must be greater than zero") //nolint:err113
+	}
+
+	if cmd.Flags().Changed("rampup-period-in-sec") && rampUpPeriodInSec <= 0 {
+		return 0, 0, errors.New("rampup-period-in-sec must be greater than zero") //nolint:err113
+	}
+
+	if maxRPS > 0 && !cmd.Flags().Changed("rampup-period-in-sec") {
+		rampUpPeriodInSec = maxRPS * tuple.RPSToRampupPeriodMultiplier
+	}
+
+	return maxRPS, rampUpPeriodInSec, nil
+}
+
+func init() {
+	syncCmd.Flags().String("file", "", "Store file to sync the store to")
+	syncCmd.Flags().String("store-id", "", "Store ID")
+	syncCmd.Flags().Bool("dry-run", false, "Show the changes that would be made without applying them")
+	syncCmd.Flags().Bool("force", false, "Apply the changes without asking for confirmation when tuples would be deleted")
+	syncCmd.Flags().Int("max-tuples-per-write", tuple.MaxTuplesPerWrite, "Max tuples per write chunk.")
+	syncCmd.Flags().Int("max-rps", 0, "The maximum requests per second.")
+	syncCmd.Flags().Int("rampup-period-in-sec", 0, "The period over which to ramp up the request rate.")
+	syncCmd.Flags().Int("max-parallel-requests", tuple.MaxParallelRequests, "Max number of requests to issue to the server in parallel.")                                                                                                         //nolint:lll
+	syncCmd.Flags().Bool("allow-external-files", false, "Allow model_file, tuple_file, tuple_files and extends references in the store file to resolve to paths outside the store file's directory. Only enable this for store files you trust.") //nolint:lll
+
+	for _, flagName := range []string{"file", "store-id"} {
+		if err := syncCmd.MarkFlagRequired(flagName); err != nil {
+			fmt.Print(err)
+			os.Exit(1)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package store
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/fga"
+	mockclient "github.com/openfga/cli/internal/mocks"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+const syncTestModel = `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`
+
+func TestSyncStore(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	storeModel := authorizationmodel.AuthzModel{}
+	require.NoError(t, storeModel.ReadFromDSLString(syncTestModel))
+
+	protoModel := openfga.AuthorizationModel{
+		Id:              "01GXSA8YR785C4FYS3C0RTG7B1",
+		SchemaVersion:   storeModel.GetSchemaVersion(),
+		TypeDefinitions: storeModel.GetTypeDefinitions(),
+	}
+
+	mockReadModel := mockclient.NewMockSdkClientReadLatestAuthorizationModelRequestInterface(mockCtrl)
+	mockReadModel.EXPECT().Options(gomock.Any()).Return(mockReadModel)
+	mockReadModel.EXPECT().Execute().Return(
+		&client.ClientReadAuthorizationModelResponse{AuthorizationModel: &protoModel}, nil)
+	mockFgaClient.EXPECT().ReadLatestAuthorizationModel(gomock.Any()).Return(mockReadModel)
+
+	expectReadPage(mockCtrl, mockFgaClient, "", &client.ClientReadResponse{
+		Tuples: []openfga.Tuple{
+			{Key: openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+			{Key: openfga.TupleKey{User: "user:beth", Relation: "viewer", Object: "document:1"}},
+		},
+	}, nil)
+
+	storeData := &storetest.StoreData{
+		Model: syncTestModel,
+		Tuples: []client.ClientContextualTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			{User: "user:carl", Relation: "viewer", Object: "document:1"},
+		},
+	}
+
+	plan, err := buildSyncPlan(t.Context(), fga.ClientConfig{StoreID: "store-1"}, mockFgaClient, storeData,
+		authorizationmodel.ModelFormatDefault)
+	require.NoError(t, err)
+
+	assert.False(t, plan.WriteModel)
+	assert.Equal(t, []client.ClientTupleKey{{User: "user:carl", Relation: "viewer", Object: "document:1"}}, plan.Writes)
+	assert.Equal(t, []client.ClientTupleKey{{User: "user:beth", Relation: "viewer", Object: "document:1"}}, plan.Deletes)
+
+	mockDelete := mockclient.NewMockSdkClientWriteRequestInterface(mockCtrl)
+	mockDelete.EXPECT().Body(client.ClientWriteRequest{
+		Deletes: []client.ClientTupleKeyWithoutCondition{{User: "user:beth", Relation: "viewer", Object: "document:1"}},
+	}).Return(mockDelete)
+	mockDelete.EXPECT().Options(gomock.Any()).Return(mockDelete)
+	mockDelete.EXPECT().Execute().Return(&client.ClientWriteResponse{}, nil)
+
+	mockWrite := mockclient.NewMockSdkClientWriteRequestInterface(mockCtrl)
+	mockWrite.EXPECT().Body(client.ClientWriteRequest{Writes: plan.Writes}).Return(mockWrite)
+	mockWrite.EXPECT().Options(gomock.Any()).Return(mockWrite)
+	mockWrite.EXPECT().Execute().Return(&client.ClientWriteResponse{}, nil)
+
+	gomock.InOrder(
+		mockFgaClient.EXPECT().Write(gomock.Any()).Return(mockDelete),
+		mockFgaClient.EXPECT().Write(gomock.Any()).Return(mockWrite),
+	)
+
+	response, err := applySyncPlan(t.Context(), mockFgaClient, plan, 0, 0, 1, 1)
+	require.NoError(t, err)
+	assert.Empty(t, response.ModelID)
+	require.NoError(t, response.failedError())
+}
+
+func TestApplySyncPlanWithFailedWrites(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	writes := []client.ClientTupleKey{{User: "user:carl", Relation: "viewer", Object: "document:1"}}
+
+	mockWrite := mockclient.NewMockSdkClientWriteRequestInterface(mockCtrl)
+	mockWrite.EXPECT().Body(gomock.Any()).Return(mockWrite)
+	mockWrite.EXPECT().Options(gomock.Any()).Return(mockWrite)
+	mockWrite.EXPECT().Execute().Return(&client.ClientWriteResponse{
+		Writes: []client.ClientWriteRequestWriteResponse{{
+			TupleKey: writes[0],
+			Status:   client.FAILURE,
+			Error:    errSyncTuplesFailed,
+		}},
+	}, nil)
+	mockFgaClient.EXPECT().Write(gomock.Any()).Return(mockWrite)
+
+	response, err := applySyncPlan(t.Context(), mockFgaClient, &syncPlan{WriteCount: 1, Writes: writes}, 10, 1, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, response.Writes.Failed, 1)
+	require.ErrorIs(t, response.failedError(), errSyncTuplesFailed)
+}
@@ -0,0 +1,158 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuple
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+type convertResponse struct {
+	Input      string `json:"input"`
+	Output     string `json:"output"`
+	TupleCount int    `json:"tuple_count"`
+}
+
+// convertTuples copies every tuple from reader to writer, and returns how many were copied.
+func convertTuples(reader tuplefile.Reader, writer tuplefile.Writer) (int, error) {
+	count := 0
+
+	for {
+		tuple, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return count, err //nolint:wrapcheck
+		}
+
+		if err := writer.Write(tuple); err != nil {
+			return count, err //nolint:wrapcheck
+		}
+
+		count++
+	}
+
+	if err := writer.Flush(); err != nil {
+		return count, fmt.Errorf("failed to write tuples due to %w", err)
+	}
+
+	return count, nil
+}
+
+func convertTupleFile(inputFile, outputFile string, columnMapping map[string]string) (*convertResponse, error) {
+	if sameFile(inputFile, outputFile) {
+		return nil, clierrors.ValidationError("tuple convert", //nolint:wrapcheck
+			"the input and output files must be different")
+	}
+
+	if err := tuplefile.ValidateDocumentFormat(outputFile); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	inFile, err := os.Open(inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %q due to %w", inputFile, err)
+	}
+	defer inFile.Close()
+
+	reader, err := tuplefile.NewReader(inputFile, inFile, columnMapping)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	outFile, err := os.Create(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create file %q due to %w", outputFile, err)
+	}
+	defer outFile.Close()
+
+	writer, err := tuplefile.NewDocumentWriter(outputFile, outFile)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	count, err := convertTuples(reader, writer)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := outFile.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close file %q due to %w", outputFile, err)
+	}
+
+	return &convertResponse{Input: inputFile, Output: outputFile, TupleCount: count}, nil
+}
+
+func sameFile(first, second string) bool {
+	firstAbs, firstErr := filepath.Abs(first)
+	secondAbs, secondErr := filepath.Abs(second)
+
+	return firstErr == nil && secondErr == nil && firstAbs == secondAbs
+}
+
+// convertCmd represents the convert command.
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "Convert a tuples file to another format",
+	Long: `Convert a tuples file to another format, reading and writing one tuple at a time so that large files do not need to fit in memory. The json, yaml, jsonl and csv formats are supported, inferred from the file extensions.
+
+Use --csv-columns to read a csv file whose headers differ from user_type,user_id,user_relation,relation,object_type,object_id,condition_name,condition_context.`, //nolint:lll
+	Example: `fga tuple convert --input tuples.csv --output tuples.jsonl
+fga tuple convert --input tuples.yaml --output tuples.csv
+fga tuple convert --input export.csv --output tuples.json --csv-columns principal_type=user_type,principal_id=user_id,resource_type=object_type,resource_id=object_id`, //nolint:lll
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		inputFile, _ := cmd.Flags().GetString("input")
+		outputFile, _ := cmd.Flags().GetString("output")
+		columnMapping, _ := cmd.Flags().GetStringToString("csv-columns")
+
+		response, err := convertTupleFile(inputFile, outputFile, columnMapping)
+		if err != nil {
+			return err
+		}
+
+		return output.Display(response) //nolint:wrapcheck
+	},
+}
+
+func init() {
+	convertCmd.Flags().String("input", "", "Tuples file to convert")
+	convertCmd.Flags().String("output", "", "File to write the converted tuples to")
+	convertCmd.Flags().StringToString("csv-columns", nil, "Map the headers of the input csv file to the tuple columns, as header=column pairs") //nolint:lll
+
+	// Tuples are converted offline, so the store ID the tuple command requires is not needed
+	convertCmd.Flags().String("store-id", "", "Store ID")
+	_ = convertCmd.Flags().MarkHidden("store-id")
+
+	for _, flagName := range []string{"input", "output"} {
+		if err := convertCmd.MarkFlagRequired(flagName); err != nil {
+			fmt.Printf("error setting flag as required - %v: %v\n", "cmd/tuple/convert", err)
+			os.Exit(1)
+		}
+	}
+}
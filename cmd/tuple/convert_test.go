@@ -0,0 +1,80 @@
+package tuple
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+func TestConvertTupleFile(t *testing.T) {
+	t.Parallel()
+
+	inputs := []string{
+		"testdata/tuples.csv",
+		"testdata/tuples.json",
+		"testdata/tuples.jsonl",
+		"testdata/tuples.yaml",
+	}
+	outputs := []string{"tuples.csv", "tuples.json", "tuples.jsonl", "tuples.yaml"}
+
+	for _, inputFile := range inputs {
+		expected, err := tuplefile.ReadTupleFile(inputFile)
+		require.NoError(t, err)
+
+		for _, outputName := range outputs {
+			t.Run(filepath.Base(inputFile)+" to "+outputName, func(t *testing.T) {
+				t.Parallel()
+
+				outputFile := filepath.Join(t.TempDir(), outputName)
+
+				response, err := convertTupleFile(inputFile, outputFile, nil)
+				require.NoError(t, err)
+				assert.Equal(t, len(expected), response.TupleCount)
+
+				converted, err := tuplefile.ReadTupleFile(outputFile)
+				require.NoError(t, err)
+				assert.Equal(t, expected, converted)
+			})
+		}
+	}
+}
+
+func TestConvertTupleFileWithColumnMapping(t *testing.T) {
+	t.Parallel()
+
+	expected, err := tuplefile.ReadTupleFile("testdata/tuples.csv")
+	require.NoError(t, err)
+
+	outputFile := filepath.Join(t.TempDir(), "tuples.jsonl")
+
+	_, err = convertTupleFile("testdata/tuples_mapped_headers.csv", outputFile, map[string]string{
+		"principal_type": "user_type",
+		"principal_id":   "user_id",
+		"resource_type":  "object_type",
+		"resource_id":    "object_id",
+	})
+	require.NoError(t, err)
+
+	converted, err := tuplefile.ReadTupleFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, expected, converted)
+}
+
+func TestConvertTupleFileErrors(t *testing.T) {
+	t.Parallel()
+
+	outputFile := filepath.Join(t.TempDir(), "tuples.jsonl")
+
+	_, err := convertTupleFile("testdata/tuples.csv", "testdata/tuples.csv", nil)
+	require.EqualError(t, err, "validation error - tuple convert: the input and output files must be different")
+
+	_, err = convertTupleFile("testdata/tuples.csv", "tuples.toml", nil)
+	require.EqualError(t, err, `unsupported file format ".toml"`)
+
+	_, err = convertTupleFile("testdata/tuples_missing_required_headers.csv", outputFile, nil)
+	require.ErrorContains(t, err, "failed to parse input tuples")
+}
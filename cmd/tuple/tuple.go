@@ -28,7 +28,7 @@ import (
 var TupleCmd = &cobra.Command{
 	Use:   "tuple",
 	Short: "Interact with Relationship Tuples",
-	Long:  "Read, write, delete, import and listen to changes in relationship tuples in a store.",
+	Long:  "Read, write, delete, import and listen to changes in relationship tuples in a store, and validate and convert tuple files.",
 }
 
 func init() {
@@ -36,6 +36,8 @@ func init() {
 	TupleCmd.AddCommand(readCmd)
 	TupleCmd.AddCommand(writeCmd)
 	TupleCmd.AddCommand(deleteCmd)
+	TupleCmd.AddCommand(validateCmd)
+	TupleCmd.AddCommand(convertCmd)
 
 	TupleCmd.PersistentFlags().String("store-id", "", "Store ID")
 
@@ -0,0 +1,110 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tuple
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/clierrors"
+	"github.com/openfga/cli/internal/output"
+	"github.com/openfga/cli/internal/tuple"
+	"github.com/openfga/cli/internal/tuplefile"
+)
+
+// invalidTuplesError displays the report of the invalid tuples, and returns the error to exit with.
+func invalidTuplesError(operation string, report *tuple.ValidationReport) error {
+	if err := output.Display(report); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return clierrors.ValidationError(operation, //nolint:wrapcheck
+		fmt.Sprintf("%d of %d tuples are not valid for the model", report.InvalidCount, report.TotalCount))
+}
+
+func readValidationModel(
+	fileName string,
+	format authorizationmodel.ModelFormat,
+) (*authorizationmodel.AuthzModel, error) {
+	var input, storeName string
+
+	if err := authorizationmodel.ReadFromFile(fileName, &input, &format, &storeName); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	authModel := &authorizationmodel.AuthzModel{}
+	if err := authModel.ReadModelFromString(input, format); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return authModel, nil
+}
+
+// validateCmd represents the validate command.
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate tuples against a model",
+	Long: `Validate the tuples of a file against a model, without calling the server.
+
+Each tuple is checked against the types and relations of the model, the directly related user types of its relation, and the parameters of its condition. The invalid tuples are reported with their index, their position among the tuples of the file, starting at 1. For csv and jsonl files, the line of the file each invalid tuple is on is reported too.`, //nolint:lll
+	Example: `fga tuple validate --file tuples.csv --model model.fga
+fga tuple validate --file tuples.jsonl --model fga.mod`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		fileName, _ := cmd.Flags().GetString("file")
+		modelFile, _ := cmd.Flags().GetString("model")
+
+		tuples, lines, err := tuplefile.ReadTupleFileLines(fileName)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		authModel, err := readValidationModel(modelFile, validateModelFormat)
+		if err != nil {
+			return err
+		}
+
+		report := tuple.ValidateLines(authModel, tuples, lines)
+		if report.InvalidCount > 0 {
+			return invalidTuplesError("tuple validate", report)
+		}
+
+		return output.Display(report) //nolint:wrapcheck
+	},
+}
+
+var validateModelFormat = authorizationmodel.ModelFormatDefault
+
+func init() {
+	validateCmd.Flags().String("file", "", "Tuples file")
+	validateCmd.Flags().String("model", "", "Model file, in the JSON or DSL format or an fga.mod file")
+	validateCmd.Flags().Var(&validateModelFormat, "format", `Authorization model input format. Can be "fga", "json", or "modular"`) //nolint:lll
+
+	// Tuples are validated offline, so the store ID the tuple command requires is not needed
+	validateCmd.Flags().String("store-id", "", "Store ID")
+	_ = validateCmd.Flags().MarkHidden("store-id")
+
+	for _, flagName := range []string{"file", "model"} {
+		if err := validateCmd.MarkFlagRequired(flagName); err != nil {
+			fmt.Printf("error setting flag as required - %v: %v\n", "cmd/tuple/validate", err)
+			os.Exit(1)
+		}
+	}
+}
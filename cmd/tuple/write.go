@@ -20,13 +20,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path"
 	"time"
 
 	"github.com/openfga/go-sdk/client"
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
+	"github.com/openfga/cli/internal/authorizationmodel"
 	"github.com/openfga/cli/internal/cmdutils"
+	"github.com/openfga/cli/internal/fga"
 	"github.com/openfga/cli/internal/output"
 	"github.com/openfga/cli/internal/tuple"
 	"github.com/openfga/cli/internal/tuplefile"
@@ -75,7 +79,10 @@ var writeCmd = &cobra.Command{
   fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv
   fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --max-tuples-per-write 10 --max-parallel-requests 5
   fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --max-rps 10
-  fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --on-duplicate ignore`,
+  fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --on-duplicate ignore
+  fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --validate
+  fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --failed-output failed.csv
+  fga tuple write --store-id=01H0H015178Y2V4CX10C2KGHF4 --file tuples.csv --checkpoint-file tuples.checkpoint --resume`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		clientConfig := cmdutils.GetClientConfig(cmd)
 
@@ -141,6 +148,10 @@ func writeTuplesFromArgs(cmd *cobra.Command, args []string, fgaClient *client.Op
 		},
 	}
 
+	if err := validateTuplesWithStoreModel(cmd.Context(), cmd.Flags(), fgaClient, body); err != nil {
+		return err
+	}
+
 	_, err = fgaClient.
 		WriteTuples(context.Background()).
 		Body(body).
@@ -240,11 +251,19 @@ func writeTuplesFromFile(ctx context.Context, flags *flag.FlagSet, fgaClient *cl
 		return fmt.Errorf("failed to parse debug flag due to %w", err)
 	}
 
+	if err := validateFailedOutputFormat(flags, fileName); err != nil {
+		return err
+	}
+
 	tuples, err := tuplefile.ReadTupleFile(fileName)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
 
+	if err := validateTuplesWithStoreModel(ctx, flags, fgaClient, tuples); err != nil {
+		return err
+	}
+
 	writeRequest := client.ClientWriteRequest{
 		Writes: tuples,
 	}
@@ -259,14 +278,23 @@ func writeTuplesFromFile(ctx context.Context, flags *flag.FlagSet, fgaClient *cl
 
 	newCtx := utils.WithDebugContext(ctx, debug)
 
-	response, err := tuple.ImportTuples(
+	checkpoint, err := openImportCheckpoint(flags)
+	if err != nil {
+		return err
+	}
+
+	response, err := tuple.ImportTuplesWithCheckpoint(
 		newCtx, fgaClient,
 		tuple.DefaultMinRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests,
-		writeRequest, options)
+		writeRequest, options, checkpoint)
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
 
+	if err := writeFailedOutput(flags, fileName, response); err != nil {
+		return err
+	}
+
 	duration := time.Since(startTime)
 	timeSpent := duration.String()
 
@@ -283,11 +311,131 @@ func writeTuplesFromFile(ctx context.Context, flags *flag.FlagSet, fgaClient *cl
 	outputResponse["total_count"] = len(tuples)
 	outputResponse["successful_count"] = len(response.Successful)
 	outputResponse["failed_count"] = len(response.Failed)
+
+	if checkpoint != nil {
+		outputResponse["skipped_count"] = response.SkippedCount
+	}
 	outputResponse["time_spent"] = timeSpent
 
 	return output.Display(outputResponse) //nolint:wrapcheck
 }
 
+// writeFailedOutput writes the tuples that failed to import to the --failed-output file, in the format
+// of the input file, so that they can be fixed and imported again.
+func writeFailedOutput(flags *flag.FlagSet, inputFileName string, response *tuple.ImportResponse) error {
+	failedOutput, err := flags.GetString("failed-output")
+	if err != nil {
+		return fmt.Errorf("failed to parse failed-output due to %w", err)
+	}
+
+	if failedOutput == "" {
+		return nil
+	}
+
+	failedTuples := make([]tuplefile.FailedTuple, 0, len(response.Failed))
+	for _, failed := range response.Failed {
+		failedTuples = append(failedTuples, tuplefile.FailedTuple{Tuple: failed.TupleKey, Reason: failed.Reason})
+	}
+
+	file, err := os.Create(failedOutput)
+	if err != nil {
+		return fmt.Errorf("failed to create failed-output file due to %w", err)
+	}
+	defer file.Close()
+
+	if err := tuplefile.WriteFailedTuples(inputFileName, file, failedTuples); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write failed-output file due to %w", err)
+	}
+
+	return nil
+}
+
+func validateFailedOutputFormat(flags *flag.FlagSet, inputFileName string) error {
+	failedOutput, err := flags.GetString("failed-output")
+	if err != nil {
+		return fmt.Errorf("failed to parse failed-output due to %w", err)
+	}
+
+	if failedOutput != "" && normalizedExt(failedOutput) != normalizedExt(inputFileName) {
+		return fmt.Errorf( //nolint:err113
+			"failed-output file %q must have the same format as the input file %q", failedOutput, inputFileName)
+	}
+
+	return nil
+}
+
+func normalizedExt(fileName string) string {
+	ext := path.Ext(fileName)
+	if ext == ".yml" {
+		return ".yaml"
+	}
+
+	return ext
+}
+
+// validateTuplesWithStoreModel validates the tuples against the model of --model-id, or the latest
+// model of the store, when --validate is set. No tuple is written if any is invalid.
+func validateTuplesWithStoreModel(
+	ctx context.Context,
+	flags *flag.FlagSet,
+	fgaClient client.SdkClient,
+	tuples []client.ClientTupleKey,
+) error {
+	if validate, _ := flags.GetBool("validate"); !validate {
+		return nil
+	}
+
+	modelID, _ := flags.GetString("model-id")
+
+	response, err := authorizationmodel.ReadFromStore(ctx, fga.ClientConfig{AuthorizationModelID: modelID}, fgaClient)
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	authModel := authorizationmodel.AuthzModel{}
+	authModel.Set(*response.AuthorizationModel)
+
+	report := tuple.Validate(&authModel, tuples)
+	if report.InvalidCount > 0 {
+		return invalidTuplesError("tuple write", report)
+	}
+
+	return nil
+}
+
+// openImportCheckpoint returns the checkpoint to record the import progress in, or nil when
+// --checkpoint-file is not set.
+func openImportCheckpoint(flags *flag.FlagSet) (*tuple.ImportCheckpoint, error) {
+	checkpointFile, err := flags.GetString("checkpoint-file")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint-file due to %w", err)
+	}
+
+	resume, err := flags.GetBool("resume")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse resume due to %w", err)
+	}
+
+	if checkpointFile == "" {
+		if resume {
+			return nil, errors.New("--resume requires --checkpoint-file") //nolint:err113
+		}
+
+		return nil, nil //nolint:nilnil
+	}
+
+	checkpoint, err := tuple.OpenImportCheckpoint(checkpointFile, resume)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return checkpoint, nil
+}
+
 var onDuplicateWriteOption tuple.ClientWriteRequestOnDuplicateWrites
 
 func init() {
@@ -302,5 +450,10 @@ func init() {
 	writeCmd.Flags().Int("max-rps", 0, "The maximum requests per second.")
 	writeCmd.Flags().Int("rampup-period-in-sec", 0, "The period over which to ramp up the request rate.")
 
+	writeCmd.Flags().String("failed-output", "", "File to write the tuples that failed to import to, in the same format as the input file, with the failure reason")
+	writeCmd.Flags().String("checkpoint-file", "", "File to record import progress in, so an interrupted import can be resumed with --resume")
+	writeCmd.Flags().Bool("resume", false, "Skip the chunks of tuples that --checkpoint-file records as already imported")
+
+	writeCmd.Flags().Bool("validate", false, "Validate the tuples against the model before writing them, and write none if any is invalid")
 	writeCmd.Flags().BoolVar(&hideImportedTuples, "hide-imported-tuples", false, "Hide successfully imported tuples from output")
 }
@@ -0,0 +1,282 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizationmodel
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	openfga "github.com/openfga/go-sdk"
+)
+
+// ErrInvalidTuple is returned when a tuple cannot be written with a model.
+var ErrInvalidTuple = errors.New("tuple is not valid for the model")
+
+const schemaVersion10 = "1.0"
+
+// BreakingChange is a change between two models that can leave tuples written with the
+// first one invalid in the second: a removed type, a removed relation, or a removed
+// directly assignable type.
+type BreakingChange struct {
+	Type                   string `json:"type"`
+	Relation               string `json:"relation,omitempty"`
+	DirectlyAssignableType string `json:"directly_assignable_type,omitempty"`
+	Reason                 string `json:"reason"`
+}
+
+// BreakingChanges returns the changes in the diff that can strand existing tuples.
+func (diff *ModelDiff) BreakingChanges() []BreakingChange {
+	changes := []BreakingChange{}
+
+	for _, typeName := range diff.RemovedTypes {
+		changes = append(changes, BreakingChange{Type: typeName, Reason: "type removed"})
+	}
+
+	for _, typeDiff := range diff.ChangedTypes {
+		for _, relation := range typeDiff.RemovedRelations {
+			changes = append(changes, BreakingChange{Type: typeDiff.Type, Relation: relation, Reason: "relation removed"})
+		}
+
+		for _, change := range typeDiff.ChangedRelations {
+			for _, directType := range change.RemovedDirectTypes {
+				changes = append(changes, BreakingChange{
+					Type:                   typeDiff.Type,
+					Relation:               change.Relation,
+					DirectlyAssignableType: directType,
+					Reason:                 "directly assignable type removed",
+				})
+			}
+		}
+	}
+
+	return changes
+}
+
+// ValidateTuple checks that the tuple could be written with the model: the type of the object
+// must define the relation, and the user, along with the condition of the tuple, must match
+// one of the directly assignable types of that relation. The condition context may only set
+// parameters of the condition, with values of their type.
+func (model *AuthzModel) ValidateTuple(key openfga.TupleKey) error {
+	objectType, _, found := strings.Cut(key.Object, ":")
+	if !found {
+		return fmt.Errorf("%w: object %q is not in the type:id format", ErrInvalidTuple, key.Object)
+	}
+
+	typeDefs := model.GetTypeDefinitions()
+
+	typeIndex := slices.IndexFunc(typeDefs, func(typeDef openfga.TypeDefinition) bool {
+		return typeDef.Type == objectType
+	})
+	if typeIndex < 0 {
+		return fmt.Errorf("%w: type %q is not defined", ErrInvalidTuple, objectType)
+	}
+
+	typeDef := typeDefs[typeIndex]
+	if _, ok := typeDef.GetRelations()[key.Relation]; !ok {
+		return fmt.Errorf("%w: relation %q is not defined on type %q", ErrInvalidTuple, key.Relation, objectType)
+	}
+
+	// 1.0 models do not restrict the types of users that can be assigned
+	if model.GetSchemaVersion() == schemaVersion10 {
+		return nil
+	}
+
+	userReference, err := tupleUserReference(key)
+	if err != nil {
+		return err
+	}
+
+	metadata := typeDef.GetMetadata()
+	relationMetadata := metadata.GetRelations()[key.Relation]
+
+	for _, reference := range relationMetadata.GetDirectlyRelatedUserTypes() {
+		if relationReferenceString(reference) == userReference {
+			return model.validateConditionContext(key.Condition)
+		}
+	}
+
+	return fmt.Errorf("%w: %s is not a directly assignable type of %s#%s",
+		ErrInvalidTuple, userReference, objectType, key.Relation)
+}
+
+// tupleUserReference returns the user of the tuple in the form of a directly assignable type,
+// e.g. "user", "user:*", "team#member" or "user with in_range".
+func tupleUserReference(key openfga.TupleKey) (string, error) {
+	userType, userID, found := strings.Cut(key.User, ":")
+	if !found {
+		return "", fmt.Errorf("%w: user %q is not in the type:id format", ErrInvalidTuple, key.User)
+	}
+
+	reference := userType
+
+	switch _, userRelation, hasRelation := strings.Cut(userID, "#"); {
+	case hasRelation:
+		reference += "#" + userRelation
+	case userID == "*":
+		reference += ":*"
+	}
+
+	if key.Condition != nil && key.Condition.Name != "" {
+		reference += " with " + key.Condition.Name
+	}
+
+	return reference, nil
+}
+
+// validateConditionContext checks the context of a tuple's condition against the parameters of
+// the condition. Parameters left out of the context are not an error, they can be set when
+// checking.
+func (model *AuthzModel) validateConditionContext(condition *openfga.RelationshipCondition) error {
+	if condition == nil || condition.Context == nil {
+		return nil
+	}
+
+	modelCondition, ok := model.Conditions[condition.Name]
+	if !ok || modelCondition == nil {
+		return fmt.Errorf("%w: condition %q is not defined", ErrInvalidTuple, condition.Name)
+	}
+
+	parameters := modelCondition.GetParameters()
+
+	for _, name := range slices.Sorted(maps.Keys(*condition.Context)) {
+		parameter, ok := parameters[name]
+		if !ok {
+			return fmt.Errorf("%w: parameter %q is not defined on condition %q", ErrInvalidTuple, name, condition.Name)
+		}
+
+		if !conditionValueHasType((*condition.Context)[name], parameter) {
+			return fmt.Errorf("%w: parameter %q of condition %q must be of type %s",
+				ErrInvalidTuple, name, condition.Name, conditionParameterType(parameter))
+		}
+	}
+
+	return nil
+}
+
+// conditionValueHasType returns whether a value decoded from JSON or YAML can be given to a
+// condition parameter of the given type.
+func conditionValueHasType(value any, typeRef openfga.ConditionParamTypeRef) bool { //nolint:cyclop
+	switch typeRef.TypeName {
+	case openfga.TYPENAME_BOOL:
+		_, ok := value.(bool)
+
+		return ok
+	case openfga.TYPENAME_STRING:
+		_, ok := value.(string)
+
+		return ok
+	case openfga.TYPENAME_INT:
+		number, ok := conditionNumber(value)
+
+		return ok && number == math.Trunc(number)
+	case openfga.TYPENAME_UINT:
+		number, ok := conditionNumber(value)
+
+		return ok && number == math.Trunc(number) && number >= 0
+	case openfga.TYPENAME_DOUBLE:
+		_, ok := conditionNumber(value)
+
+		return ok
+	case openfga.TYPENAME_DURATION:
+		text, ok := value.(string)
+		if ok {
+			_, err := time.ParseDuration(text)
+			ok = err == nil
+		}
+
+		return ok
+	case openfga.TYPENAME_TIMESTAMP:
+		text, ok := value.(string)
+		if ok {
+			_, err := time.Parse(time.RFC3339, text)
+			ok = err == nil
+		}
+
+		return ok
+	case openfga.TYPENAME_IPADDRESS:
+		text, ok := value.(string)
+		if ok {
+			_, err := netip.ParseAddr(text)
+			ok = err == nil
+		}
+
+		return ok
+	case openfga.TYPENAME_LIST:
+		return conditionCollectionHasType(value, typeRef, false)
+	case openfga.TYPENAME_MAP:
+		return conditionCollectionHasType(value, typeRef, true)
+	default:
+		return true
+	}
+}
+
+func conditionCollectionHasType(value any, typeRef openfga.ConditionParamTypeRef, isMap bool) bool {
+	elementType := openfga.ConditionParamTypeRef{TypeName: openfga.TYPENAME_ANY}
+	if genericTypes := typeRef.GetGenericTypes(); len(genericTypes) > 0 {
+		elementType = genericTypes[0]
+	}
+
+	var elements []any
+
+	switch collection := value.(type) {
+	case []any:
+		if isMap {
+			return false
+		}
+
+		elements = collection
+	case map[string]any:
+		if !isMap {
+			return false
+		}
+
+		for _, element := range collection {
+			elements = append(elements, element)
+		}
+	default:
+		return false
+	}
+
+	for _, element := range elements {
+		if !conditionValueHasType(element, elementType) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func conditionNumber(value any) (float64, bool) {
+	switch number := value.(type) {
+	case float64:
+		return number, true
+	case int:
+		return float64(number), true
+	case int64:
+		return float64(number), true
+	case uint64:
+		return float64(number), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,138 @@
+package authorizationmodel_test
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+const compatibilityTestModel = `model
+  schema 1.1
+
+type user
+
+type team
+  relations
+    define member: [user]
+
+type document
+  relations
+    define owner: [user]
+    define viewer: [user, user:*, team#member, user with in_range] or owner
+
+condition in_range(x: int) {
+  x < 100
+}
+`
+
+func TestBreakingChanges(t *testing.T) {
+	t.Parallel()
+
+	from := readDSLModel(t, compatibilityTestModel)
+	to := readDSLModel(t, `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user, user with in_range]
+    define editor: [user]
+
+condition in_range(x: int) {
+  x < 100
+}
+`)
+
+	diff, err := authorizationmodel.Diff(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, []authorizationmodel.BreakingChange{
+		{Type: "team", Reason: "type removed"},
+		{Type: "document", Relation: "owner", Reason: "relation removed"},
+		{Type: "document", Relation: "viewer", DirectlyAssignableType: "team#member", Reason: "directly assignable type removed"},
+		{Type: "document", Relation: "viewer", DirectlyAssignableType: "user:*", Reason: "directly assignable type removed"},
+	}, diff.BreakingChanges())
+
+	same, err := authorizationmodel.Diff(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, same.BreakingChanges())
+}
+
+func TestValidateTuple(t *testing.T) {
+	t.Parallel()
+
+	model := readDSLModel(t, compatibilityTestModel)
+
+	tests := []struct {
+		name  string
+		key   openfga.TupleKey
+		valid bool
+	}{
+		{"user", openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"}, true},
+		{"wildcard", openfga.TupleKey{User: "user:*", Relation: "viewer", Object: "document:1"}, true},
+		{"userset", openfga.TupleKey{User: "team:a#member", Relation: "viewer", Object: "document:1"}, true},
+		{
+			"condition",
+			openfga.TupleKey{
+				User: "user:anne", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "in_range"},
+			},
+			true,
+		},
+		{
+			"condition context",
+			openfga.TupleKey{
+				User: "user:anne", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{"x": float64(10)}},
+			},
+			true,
+		},
+		{
+			"condition context with a value of the wrong type",
+			openfga.TupleKey{
+				User: "user:anne", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{"x": "ten"}},
+			},
+			false,
+		},
+		{
+			"condition context with an unknown parameter",
+			openfga.TupleKey{
+				User: "user:anne", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "in_range", Context: &map[string]any{"y": float64(10)}},
+			},
+			false,
+		},
+		{
+			"unknown condition",
+			openfga.TupleKey{
+				User: "user:anne", Relation: "viewer", Object: "document:1",
+				Condition: &openfga.RelationshipCondition{Name: "other"},
+			},
+			false,
+		},
+		{"wildcard not allowed", openfga.TupleKey{User: "user:*", Relation: "owner", Object: "document:1"}, false},
+		{"user type not allowed", openfga.TupleKey{User: "team:a", Relation: "viewer", Object: "document:1"}, false},
+		{"unknown relation", openfga.TupleKey{User: "user:anne", Relation: "editor", Object: "document:1"}, false},
+		{"unknown type", openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "folder:1"}, false},
+		{"malformed object", openfga.TupleKey{User: "user:anne", Relation: "viewer", Object: "document"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := model.ValidateTuple(test.key)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, authorizationmodel.ErrInvalidTuple)
+			}
+		})
+	}
+}
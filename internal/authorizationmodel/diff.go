@@ -0,0 +1,491 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authorizationmodel
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	pb "github.com/openfga/api/proto/openfga/v1"
+	openfga "github.com/openfga/go-sdk"
+	language "github.com/openfga/language/pkg/go/transformer"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+var relationDefinitionRegex = regexp.MustCompile(`(?m)^\s+define\s+([^\s:]+)\s*:\s*(.*?)\s*$`)
+
+// RelationChange is a relation whose definition differs between two models. Definitions are
+// shown in the DSL syntax, e.g. "[user] or editor". A change is either to the rewrite (how the
+// relation is computed from other relations), to the types that can be directly assigned, or both.
+type RelationChange struct {
+	Relation           string   `json:"relation"`
+	From               string   `json:"from"`
+	To                 string   `json:"to"`
+	RewriteChanged     bool     `json:"rewrite_changed"`
+	AddedDirectTypes   []string `json:"added_directly_assignable_types,omitempty"`
+	RemovedDirectTypes []string `json:"removed_directly_assignable_types,omitempty"`
+}
+
+// relationDefinition is a relation in a form that can be compared regardless of how the model was written.
+type relationDefinition struct {
+	// definition is the DSL definition, used for display
+	definition string
+	// rewrite is the JSON of the relation rewrite, with the operands of unions and intersections sorted
+	rewrite string
+	// directTypes are the directly assignable types in DSL syntax, sorted
+	directTypes []string
+}
+
+// TypeDiff holds the relations added, removed and changed in a type present in both models.
+type TypeDiff struct {
+	Type             string           `json:"type"`
+	AddedRelations   []string         `json:"added_relations,omitempty"`
+	RemovedRelations []string         `json:"removed_relations,omitempty"`
+	ChangedRelations []RelationChange `json:"changed_relations,omitempty"`
+}
+
+// ConditionChange is a condition whose expression or parameters differ between two models.
+type ConditionChange struct {
+	Name string            `json:"name"`
+	From openfga.Condition `json:"from"`
+	To   openfga.Condition `json:"to"`
+}
+
+// ModelDiff describes how the types and conditions of one model differ from another.
+type ModelDiff struct {
+	FromID            string            `json:"from_id,omitempty"`
+	ToID              string            `json:"to_id,omitempty"`
+	AddedTypes        []string          `json:"added_types,omitempty"`
+	RemovedTypes      []string          `json:"removed_types,omitempty"`
+	ChangedTypes      []TypeDiff        `json:"changed_types,omitempty"`
+	AddedConditions   []string          `json:"added_conditions,omitempty"`
+	RemovedConditions []string          `json:"removed_conditions,omitempty"`
+	ChangedConditions []ConditionChange `json:"changed_conditions,omitempty"`
+}
+
+// HasChanges reports whether the two models differ in any type or condition.
+func (diff *ModelDiff) HasChanges() bool {
+	return len(diff.AddedTypes) > 0 || len(diff.RemovedTypes) > 0 || len(diff.ChangedTypes) > 0 ||
+		len(diff.AddedConditions) > 0 || len(diff.RemovedConditions) > 0 || len(diff.ChangedConditions) > 0
+}
+
+// Diff compares the type definitions and conditions of two models. Relations are compared
+// semantically: the order of directly assignable types and of union and intersection operands
+// does not matter, and the same relation written in JSON or in the DSL, or split across
+// modules, compares as equal.
+func Diff(from *AuthzModel, to *AuthzModel) (*ModelDiff, error) {
+	diff := &ModelDiff{FromID: from.GetID(), ToID: to.GetID()}
+
+	fromTypes, err := typeRelationDefinitions(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toTypes, err := typeRelationDefinitions(to)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, typeName := range sortedKeys(fromTypes) {
+		if _, ok := toTypes[typeName]; !ok {
+			diff.RemovedTypes = append(diff.RemovedTypes, typeName)
+		}
+	}
+
+	for _, typeName := range sortedKeys(toTypes) {
+		fromRelations, ok := fromTypes[typeName]
+		if !ok {
+			diff.AddedTypes = append(diff.AddedTypes, typeName)
+
+			continue
+		}
+
+		if typeDiff := diffRelations(typeName, fromRelations, toTypes[typeName]); typeDiff != nil {
+			diff.ChangedTypes = append(diff.ChangedTypes, *typeDiff)
+		}
+	}
+
+	diffConditions(diff, *from.GetConditions(), *to.GetConditions())
+
+	return diff, nil
+}
+
+// FriendlyDisplay renders the diff for humans, one change per line: "+" for additions,
+// "-" for removals and "~" for changes.
+func (diff *ModelDiff) FriendlyDisplay() string {
+	if !diff.HasChanges() {
+		return "No changes"
+	}
+
+	lines := []string{}
+
+	for _, typeName := range diff.AddedTypes {
+		lines = append(lines, "+ type "+typeName)
+	}
+
+	for _, typeName := range diff.RemovedTypes {
+		lines = append(lines, "- type "+typeName)
+	}
+
+	for _, typeDiff := range diff.ChangedTypes {
+		lines = append(lines, "~ type "+typeDiff.Type)
+
+		for _, relation := range typeDiff.AddedRelations {
+			lines = append(lines, "    + relation "+relation)
+		}
+
+		for _, relation := range typeDiff.RemovedRelations {
+			lines = append(lines, "    - relation "+relation)
+		}
+
+		for _, change := range typeDiff.ChangedRelations {
+			lines = append(lines,
+				"    ~ relation "+change.Relation,
+				"        from: "+change.From,
+				"        to:   "+change.To)
+
+			if change.RewriteChanged {
+				lines = append(lines, "        rewrite changed")
+			}
+
+			if len(change.AddedDirectTypes) > 0 {
+				lines = append(lines, "        directly assignable types added: "+strings.Join(change.AddedDirectTypes, ", "))
+			}
+
+			if len(change.RemovedDirectTypes) > 0 {
+				lines = append(lines,
+					"        directly assignable types removed: "+strings.Join(change.RemovedDirectTypes, ", "))
+			}
+		}
+	}
+
+	for _, condition := range diff.AddedConditions {
+		lines = append(lines, "+ condition "+condition)
+	}
+
+	for _, condition := range diff.RemovedConditions {
+		lines = append(lines, "- condition "+condition)
+	}
+
+	for _, change := range diff.ChangedConditions {
+		lines = append(lines,
+			"~ condition "+change.Name,
+			"    from: "+conditionSignature(change.From),
+			"    to:   "+conditionSignature(change.To))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// conditionSignature returns the parameters and expression of a condition, e.g. "(x: int) x < 100".
+func conditionSignature(condition openfga.Condition) string {
+	parameters := condition.GetParameters()
+	names := sortedKeys(parameters)
+
+	signatures := make([]string, 0, len(names))
+	for _, name := range names {
+		parameter := parameters[name]
+		signatures = append(signatures, name+": "+conditionParameterType(parameter))
+	}
+
+	return "(" + strings.Join(signatures, ", ") + ") " + condition.Expression
+}
+
+func conditionParameterType(parameter openfga.ConditionParamTypeRef) string {
+	typeName := strings.ToLower(strings.TrimPrefix(string(parameter.TypeName), "TYPE_NAME_"))
+
+	genericTypes := parameter.GetGenericTypes()
+	if len(genericTypes) == 0 {
+		return typeName
+	}
+
+	generics := make([]string, 0, len(genericTypes))
+	for _, generic := range genericTypes {
+		generics = append(generics, conditionParameterType(generic))
+	}
+
+	return typeName + "<" + strings.Join(generics, ", ") + ">"
+}
+
+func diffRelations(typeName string, fromRelations, toRelations map[string]relationDefinition) *TypeDiff {
+	typeDiff := TypeDiff{Type: typeName}
+
+	for _, relation := range sortedKeys(fromRelations) {
+		if _, ok := toRelations[relation]; !ok {
+			typeDiff.RemovedRelations = append(typeDiff.RemovedRelations, relation)
+		}
+	}
+
+	for _, relation := range sortedKeys(toRelations) {
+		fromDefinition, ok := fromRelations[relation]
+		if !ok {
+			typeDiff.AddedRelations = append(typeDiff.AddedRelations, relation)
+
+			continue
+		}
+
+		toDefinition := toRelations[relation]
+		change := RelationChange{
+			Relation:           relation,
+			From:               fromDefinition.definition,
+			To:                 toDefinition.definition,
+			RewriteChanged:     fromDefinition.rewrite != toDefinition.rewrite,
+			AddedDirectTypes:   missingFrom(toDefinition.directTypes, fromDefinition.directTypes),
+			RemovedDirectTypes: missingFrom(fromDefinition.directTypes, toDefinition.directTypes),
+		}
+
+		if change.RewriteChanged || len(change.AddedDirectTypes) > 0 || len(change.RemovedDirectTypes) > 0 {
+			typeDiff.ChangedRelations = append(typeDiff.ChangedRelations, change)
+		}
+	}
+
+	if len(typeDiff.AddedRelations) == 0 && len(typeDiff.RemovedRelations) == 0 &&
+		len(typeDiff.ChangedRelations) == 0 {
+		return nil
+	}
+
+	return &typeDiff
+}
+
+func diffConditions(diff *ModelDiff, fromConditions, toConditions map[string]openfga.Condition) {
+	for _, name := range sortedKeys(fromConditions) {
+		if _, ok := toConditions[name]; !ok {
+			diff.RemovedConditions = append(diff.RemovedConditions, name)
+		}
+	}
+
+	for _, name := range sortedKeys(toConditions) {
+		fromCondition, ok := fromConditions[name]
+
+		switch {
+		case !ok:
+			diff.AddedConditions = append(diff.AddedConditions, name)
+		case fromCondition.Expression != toConditions[name].Expression ||
+			!reflect.DeepEqual(fromCondition.Parameters, toConditions[name].Parameters):
+			diff.ChangedConditions = append(diff.ChangedConditions, ConditionChange{
+				Name: name,
+				From: withoutConditionMetadata(fromCondition),
+				To:   withoutConditionMetadata(toConditions[name]),
+			})
+		}
+	}
+}
+
+// withoutConditionMetadata drops the module information, which does not affect how the condition is evaluated.
+func withoutConditionMetadata(condition openfga.Condition) openfga.Condition {
+	condition.Metadata = nil
+
+	return condition
+}
+
+// typeRelationDefinitions returns the definition of each relation, keyed by type and relation name.
+func typeRelationDefinitions(model *AuthzModel) (map[string]map[string]relationDefinition, error) {
+	types := map[string]map[string]relationDefinition{}
+
+	for _, typeDef := range model.GetTypeDefinitions() {
+		relations, err := relationDefinitions(model.GetSchemaVersion(), typeDef)
+		if err != nil {
+			return nil, err
+		}
+
+		types[typeDef.Type] = relations
+	}
+
+	return types, nil
+}
+
+func relationDefinitions(schemaVersion string, typeDef openfga.TypeDefinition) (map[string]relationDefinition, error) {
+	relations := map[string]relationDefinition{}
+
+	if len(typeDef.GetRelations()) == 0 {
+		return relations, nil
+	}
+
+	dslDefinitions, err := relationDSLDefinitions(schemaVersion, typeDef)
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := typeDef.GetMetadata()
+	relationsMetadata := metadata.GetRelations()
+
+	for relation, userset := range typeDef.GetRelations() {
+		rewrite, err := normalizedRewrite(userset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare relation %s#%s due to %w", typeDef.Type, relation, err)
+		}
+
+		relationMetadata := relationsMetadata[relation]
+
+		directTypes := []string{}
+		for _, reference := range relationMetadata.GetDirectlyRelatedUserTypes() {
+			directTypes = append(directTypes, relationReferenceString(reference))
+		}
+
+		sort.Strings(directTypes)
+
+		relations[relation] = relationDefinition{
+			definition:  dslDefinitions[relation],
+			rewrite:     rewrite,
+			directTypes: directTypes,
+		}
+	}
+
+	return relations, nil
+}
+
+// relationReferenceString returns a directly assignable type in the DSL syntax, e.g. "user:*" or "team#member".
+func relationReferenceString(reference openfga.RelationReference) string {
+	value := reference.Type
+
+	switch {
+	case reference.Wildcard != nil:
+		value += ":*"
+	case reference.Relation != nil:
+		value += "#" + *reference.Relation
+	}
+
+	if reference.Condition != nil && *reference.Condition != "" {
+		value += " with " + *reference.Condition
+	}
+
+	return value
+}
+
+// normalizedRewrite returns the JSON of userset with the operands of unions and intersections
+// sorted, as their order does not change how the relation is evaluated.
+func normalizedRewrite(userset openfga.Userset) (string, error) {
+	usersetJSON, err := json.Marshal(userset)
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	var rewrite any
+	if err := json.Unmarshal(usersetJSON, &rewrite); err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	normalized, err := json.Marshal(normalizeRewrite(rewrite))
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return string(normalized), nil
+}
+
+func normalizeRewrite(rewrite any) any {
+	node, ok := rewrite.(map[string]any)
+	if !ok {
+		return rewrite
+	}
+
+	for key, value := range node {
+		node[key] = normalizeRewrite(value)
+	}
+
+	for _, operator := range []string{"union", "intersection"} {
+		usersets, ok := node[operator].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		children, ok := usersets["child"].([]any)
+		if !ok {
+			continue
+		}
+
+		sort.Slice(children, func(i, j int) bool {
+			first, _ := json.Marshal(children[i])
+			second, _ := json.Marshal(children[j])
+
+			return string(first) < string(second)
+		})
+	}
+
+	return node
+}
+
+// missingFrom returns the values of sorted that are not in other.
+func missingFrom(sorted []string, other []string) []string {
+	var missing []string
+
+	for _, value := range sorted {
+		if !slices.Contains(other, value) {
+			missing = append(missing, value)
+		}
+	}
+
+	return missing
+}
+
+// relationDSLDefinitions renders a model holding only typeDef as DSL, and reads the definition
+// of each relation back from it.
+func relationDSLDefinitions(schemaVersion string, typeDef openfga.TypeDefinition) (map[string]string, error) {
+	relations := map[string]string{}
+
+	if schemaVersion == "" {
+		schemaVersion = "1.1"
+	}
+
+	// module information would make the transformer emit the type as an extension of a module
+	if typeDef.Metadata != nil {
+		metadata := *typeDef.Metadata
+		metadata.Module = nil
+		metadata.SourceInfo = nil
+		typeDef.Metadata = &metadata
+	}
+
+	modelJSON, err := json.Marshal(openfga.AuthorizationModel{
+		SchemaVersion:   schemaVersion,
+		TypeDefinitions: []openfga.TypeDefinition{typeDef},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal type %s due to %w", typeDef.Type, err)
+	}
+
+	modelPb := pb.AuthorizationModel{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(modelJSON, &modelPb); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal type %s due to %w", typeDef.Type, err)
+	}
+
+	dsl, err := language.TransformJSONProtoToDSL(&modelPb)
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform type %s due to %w", typeDef.Type, err)
+	}
+
+	for _, match := range relationDefinitionRegex.FindAllStringSubmatch(dsl, -1) {
+		relations[match[1]] = match[2]
+	}
+
+	return relations, nil
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
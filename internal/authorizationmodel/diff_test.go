@@ -0,0 +1,160 @@
+package authorizationmodel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+func readDSLModel(t *testing.T, dsl string) *authorizationmodel.AuthzModel {
+	t.Helper()
+
+	model := &authorizationmodel.AuthzModel{}
+	require.NoError(t, model.ReadFromDSLString(dsl))
+
+	return model
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	from := readDSLModel(t, `model
+  schema 1.1
+
+type user
+
+type team
+  relations
+    define member: [user]
+
+type document
+  relations
+    define owner: [user]
+    define editor: [user] or owner
+    define viewer: [user, team#member] or editor
+
+condition in_range(x: int) {
+  x < 100
+}
+
+condition stale(x: int) {
+  x < 1
+}
+`)
+
+	to := readDSLModel(t, `model
+  schema 1.1
+
+type user
+
+type folder
+  relations
+    define viewer: [user]
+
+type document
+  relations
+    define editor: [user, user with in_range] or owner
+    define viewer: [team#member, user] or editor
+    define parent: [folder]
+    define owner: [user] or parent
+
+type team
+  relations
+    define member: [user]
+
+condition in_range(x: int) {
+  x < 200
+}
+
+condition fresh(x: int) {
+  x > 1
+}
+`)
+
+	diff, err := authorizationmodel.Diff(from, to)
+	require.NoError(t, err)
+
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, []string{"folder"}, diff.AddedTypes)
+	assert.Empty(t, diff.RemovedTypes)
+	assert.Equal(t, []authorizationmodel.TypeDiff{{
+		Type:           "document",
+		AddedRelations: []string{"parent"},
+		ChangedRelations: []authorizationmodel.RelationChange{
+			{
+				Relation:         "editor",
+				From:             "[user] or owner",
+				To:               "[user, user with in_range] or owner",
+				AddedDirectTypes: []string{"user with in_range"},
+			},
+			{
+				Relation:       "owner",
+				From:           "[user]",
+				To:             "[user] or parent",
+				RewriteChanged: true,
+			},
+		},
+	}}, diff.ChangedTypes)
+	assert.Equal(t, []string{"fresh"}, diff.AddedConditions)
+	assert.Equal(t, []string{"stale"}, diff.RemovedConditions)
+	require.Len(t, diff.ChangedConditions, 1)
+	assert.Equal(t, "in_range", diff.ChangedConditions[0].Name)
+	assert.Equal(t, "x < 200", diff.ChangedConditions[0].To.Expression)
+
+	same, err := authorizationmodel.Diff(from, from)
+	require.NoError(t, err)
+	assert.False(t, same.HasChanges())
+}
+
+func TestModelDiffFriendlyDisplay(t *testing.T) {
+	t.Parallel()
+
+	from := readDSLModel(t, `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define owner: [user]
+    define viewer: [user] or owner
+
+condition in_range(x: int) {
+  x < 100
+}
+`)
+
+	to := readDSLModel(t, `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define owner: [user]
+    define viewer: [user, user:*] or owner
+
+condition in_range(x: int) {
+  x < 200
+}
+`)
+
+	diff, err := authorizationmodel.Diff(from, to)
+	require.NoError(t, err)
+
+	assert.Equal(t, `~ type document
+    ~ relation viewer
+        from: [user] or owner
+        to:   [user, user:*] or owner
+        directly assignable types added: user:*
+~ condition in_range
+    from: (x: int) x < 100
+    to:   (x: int) x < 200`, diff.FriendlyDisplay())
+
+	same, err := authorizationmodel.Diff(from, from)
+	require.NoError(t, err)
+	assert.Equal(t, "No changes", same.FriendlyDisplay())
+}
@@ -358,6 +358,28 @@ func (model *AuthzModel) DisplayAsDSL(fields []string) (*string, error) {
 	return &dslModel, nil
 }
 
+// ModularModelFiles returns the paths of the module files listed in the fga.mod file.
+func ModularModelFiles(modFile string) ([]string, error) {
+	modFileContents, err := safefile.ReadExternal(modFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fga.mod file due to %w", err)
+	}
+
+	parsedModFile, err := language.TransformModFile(string(modFileContents))
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform fga.mod file due to %w", err)
+	}
+
+	directory := filepath.Dir(modFile)
+	files := make([]string, 0, len(parsedModFile.Contents.Value))
+
+	for _, fileName := range parsedModFile.Contents.Value {
+		files = append(files, filepath.Join(directory, filepath.FromSlash(fileName.Value)))
+	}
+
+	return files, nil
+}
+
 // readModelFromModFGA reads a modular model. When containBase is non-empty, the
 // fga.mod file and each of its contents entries must resolve inside it.
 func (model *AuthzModel) readModelFromModFGA(modFile string, containBase string) error {
@@ -22,8 +22,16 @@ import (
 	"github.com/spf13/viper"
 )
 
+// SkipViperBindingAnnotation can be set on a command whose flags must only reflect
+// what was passed on the command line, and never values from the config or environment.
+const SkipViperBindingAnnotation = "skip-viper-binding"
+
 // BindViperToFlags recursively binds viper configs to cobra commands and subcommands.
 func BindViperToFlags(cmd *cobra.Command, viperInstance *viper.Viper) {
+	if cmd.Annotations[SkipViperBindingAnnotation] == "true" {
+		return
+	}
+
 	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
 		configName := flag.Name
 
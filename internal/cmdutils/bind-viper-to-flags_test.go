@@ -103,3 +103,31 @@ func TestBindViperToFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestBindViperToFlagsSkipsAnnotatedCommands(t *testing.T) {
+	t.Parallel()
+
+	root := &cobra.Command{Use: "root"}
+	root.Flags().String("api-url", "", "")
+
+	child := &cobra.Command{
+		Use:         "child",
+		Annotations: map[string]string{SkipViperBindingAnnotation: "true"},
+	}
+	child.Flags().String("api-url", "", "")
+	root.AddCommand(child)
+
+	viperInstance := viper.New()
+	viperInstance.Set("api-url", "https://api.fga.example")
+
+	BindViperToFlags(root, viperInstance)
+
+	rootValue, err := root.Flags().GetString("api-url")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.fga.example", rootValue)
+
+	childValue, err := child.Flags().GetString("api-url")
+	require.NoError(t, err)
+	assert.Empty(t, childValue)
+	assert.False(t, child.Flags().Changed("api-url"))
+}
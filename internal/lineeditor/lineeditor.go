@@ -0,0 +1,410 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lineeditor reads lines from a terminal with history and tab completion. When the input
+// is not a terminal, or the terminal cannot be switched to raw mode, lines are read as they come.
+package lineeditor
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-isatty"
+)
+
+// DefaultMaxHistory is the number of lines kept in the history.
+const DefaultMaxHistory = 1000
+
+// ErrInterrupted is returned by ReadLine when Ctrl+C is pressed.
+var ErrInterrupted = errors.New("interrupted")
+
+// CompleteFunc returns the candidates for the word being typed, given the words before it.
+// Candidates that do not start with the word are ignored. A space is added after a single
+// candidate, unless it ends with ":", as the word is then not complete yet.
+type CompleteFunc func(previousWords []string, word string) []string
+
+const (
+	keyCtrlA     = 1
+	keyCtrlC     = 3
+	keyCtrlD     = 4
+	keyCtrlE     = 5
+	keyCtrlH     = 8
+	keyTab       = 9
+	keyLineFeed  = 10
+	keyCtrlK     = 11
+	keyEnter     = 13
+	keyCtrlN     = 14
+	keyCtrlP     = 16
+	keyCtrlU     = 21
+	keyEscape    = 27
+	keyBackspace = 127
+)
+
+// Editor reads lines from its input, keeping a history of the lines read.
+type Editor struct {
+	input      *bufio.Reader
+	output     io.Writer
+	complete   CompleteFunc
+	history    []string
+	maxHistory int
+
+	// terminalFd is the file descriptor switched to raw mode while a line is read, -1 if none
+	terminalFd int
+	// interactive is true when the line is edited as it is typed
+	interactive bool
+}
+
+// New returns an editor reading from input. Lines are edited as they are typed only if input is
+// a terminal. complete may be nil.
+func New(input *os.File, output io.Writer, complete CompleteFunc) *Editor {
+	editor := newEditor(input, output, false, complete)
+
+	if isatty.IsTerminal(input.Fd()) {
+		editor.terminalFd = int(input.Fd())
+		editor.interactive = true
+	}
+
+	return editor
+}
+
+// NewReader returns an editor reading lines as they come from input, such as a script.
+func NewReader(input io.Reader, output io.Writer) *Editor {
+	return newEditor(input, output, false, nil)
+}
+
+func newEditor(input io.Reader, output io.Writer, interactive bool, complete CompleteFunc) *Editor {
+	return &Editor{
+		input:       bufio.NewReader(input),
+		output:      output,
+		complete:    complete,
+		maxHistory:  DefaultMaxHistory,
+		terminalFd:  -1,
+		interactive: interactive,
+	}
+}
+
+// Interactive returns whether the lines are edited as they are typed.
+func (editor *Editor) Interactive() bool {
+	return editor.interactive
+}
+
+// History returns the lines in the history, oldest first.
+func (editor *Editor) History() []string {
+	return slices.Clone(editor.history)
+}
+
+// AddHistory adds a line to the history, unless it is empty or repeats the last line.
+func (editor *Editor) AddHistory(line string) {
+	line = strings.TrimSpace(line)
+	if line == "" || (len(editor.history) > 0 && editor.history[len(editor.history)-1] == line) {
+		return
+	}
+
+	editor.history = append(editor.history, line)
+	if len(editor.history) > editor.maxHistory {
+		editor.history = editor.history[len(editor.history)-editor.maxHistory:]
+	}
+}
+
+// LoadHistory adds the lines of the history file to the history. A missing file is not an error.
+func (editor *Editor) LoadHistory(fileName string) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to read history file %s: %w", fileName, err)
+	}
+
+	for line := range strings.SplitSeq(string(content), "\n") {
+		editor.AddHistory(line)
+	}
+
+	return nil
+}
+
+// SaveHistory writes the history to the history file, one line per entry.
+func (editor *Editor) SaveHistory(fileName string) error {
+	content := strings.Join(editor.history, "\n")
+	if content != "" {
+		content += "\n"
+	}
+
+	if err := os.WriteFile(fileName, []byte(content), 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write history file %s: %w", fileName, err)
+	}
+
+	return nil
+}
+
+// ReadLine shows the prompt and reads a line. It returns io.EOF once the input is exhausted, or
+// when Ctrl+D is pressed on an empty line, and ErrInterrupted when Ctrl+C is pressed.
+func (editor *Editor) ReadLine(prompt string) (string, error) {
+	if !editor.interactive {
+		return editor.readPlainLine()
+	}
+
+	if editor.terminalFd >= 0 {
+		restore, err := enableRawMode(editor.terminalFd)
+		if err != nil {
+			fmt.Fprint(editor.output, prompt)
+
+			return editor.readPlainLine()
+		}
+
+		defer restore()
+	}
+
+	return editor.editLine(prompt)
+}
+
+func (editor *Editor) readPlainLine() (string, error) {
+	line, err := editor.input.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || line == "") {
+		return "", err //nolint:wrapcheck
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// lineState is the line being edited.
+type lineState struct {
+	prompt string
+	line   []rune
+	cursor int
+	// historyIndex is the history entry shown, len(history) for the line being typed
+	historyIndex int
+	// typed is the line being typed, kept while browsing the history
+	typed []rune
+}
+
+func (editor *Editor) editLine(prompt string) (string, error) { //nolint:cyclop
+	state := &lineState{prompt: prompt, historyIndex: len(editor.history)}
+
+	editor.refresh(state)
+
+	for {
+		key, _, err := editor.input.ReadRune()
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+
+		switch key {
+		case keyEnter, keyLineFeed:
+			fmt.Fprint(editor.output, "\n")
+
+			return string(state.line), nil
+		case keyCtrlC:
+			fmt.Fprint(editor.output, "^C\n")
+
+			return "", ErrInterrupted
+		case keyCtrlD:
+			if len(state.line) == 0 {
+				fmt.Fprint(editor.output, "\n")
+
+				return "", io.EOF
+			}
+
+			state.deleteForward()
+		case keyBackspace, keyCtrlH:
+			state.deleteBackward()
+		case keyCtrlA:
+			state.cursor = 0
+		case keyCtrlE:
+			state.cursor = len(state.line)
+		case keyCtrlK:
+			state.line = state.line[:state.cursor]
+		case keyCtrlU:
+			state.line = state.line[state.cursor:]
+			state.cursor = 0
+		case keyCtrlP:
+			editor.historyPrevious(state)
+		case keyCtrlN:
+			editor.historyNext(state)
+		case keyTab:
+			editor.completeLine(state)
+		case keyEscape:
+			editor.handleEscapeSequence(state)
+		default:
+			if key >= ' ' {
+				state.insert(string(key))
+			}
+		}
+
+		editor.refresh(state)
+	}
+}
+
+func (editor *Editor) handleEscapeSequence(state *lineState) {
+	prefix, err := editor.input.ReadByte()
+	if err != nil || (prefix != '[' && prefix != 'O') {
+		return
+	}
+
+	code, err := editor.input.ReadByte()
+	if err != nil {
+		return
+	}
+
+	switch code {
+	case 'A':
+		editor.historyPrevious(state)
+	case 'B':
+		editor.historyNext(state)
+	case 'C':
+		state.cursor = min(state.cursor+1, len(state.line))
+	case 'D':
+		state.cursor = max(state.cursor-1, 0)
+	case 'H':
+		state.cursor = 0
+	case 'F':
+		state.cursor = len(state.line)
+	case '3':
+		// Delete is sent as "ESC [ 3 ~"
+		if next, err := editor.input.ReadByte(); err == nil && next == '~' {
+			state.deleteForward()
+		}
+	}
+}
+
+func (editor *Editor) refresh(state *lineState) {
+	fmt.Fprintf(editor.output, "\r%s%s\x1b[K", state.prompt, string(state.line))
+
+	if back := len(state.line) - state.cursor; back > 0 {
+		fmt.Fprintf(editor.output, "\x1b[%dD", back)
+	}
+}
+
+func (editor *Editor) historyPrevious(state *lineState) {
+	if state.historyIndex == 0 {
+		return
+	}
+
+	if state.historyIndex == len(editor.history) {
+		state.typed = state.line
+	}
+
+	state.historyIndex--
+	state.setLine(editor.history[state.historyIndex])
+}
+
+func (editor *Editor) historyNext(state *lineState) {
+	if state.historyIndex >= len(editor.history) {
+		return
+	}
+
+	state.historyIndex++
+	if state.historyIndex == len(editor.history) {
+		state.setLine(string(state.typed))
+
+		return
+	}
+
+	state.setLine(editor.history[state.historyIndex])
+}
+
+// completeLine completes the word before the cursor: a single candidate is inserted in full, several
+// candidates are completed up to their common prefix, or listed when there is nothing to add.
+func (editor *Editor) completeLine(state *lineState) {
+	if editor.complete == nil {
+		return
+	}
+
+	before := string(state.line[:state.cursor])
+	words := strings.Fields(before)
+	word := ""
+
+	if len(words) > 0 && !strings.HasSuffix(before, " ") {
+		word = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	candidates := []string{}
+
+	for _, candidate := range editor.complete(words, word) {
+		if strings.HasPrefix(candidate, word) && !slices.Contains(candidates, candidate) {
+			candidates = append(candidates, candidate)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		fmt.Fprint(editor.output, "\a")
+	case 1:
+		completion := strings.TrimPrefix(candidates[0], word)
+		if !strings.HasSuffix(completion, ":") {
+			completion += " "
+		}
+
+		state.insert(completion)
+	default:
+		prefix := commonPrefix(candidates)
+		if len(prefix) > len(word) {
+			state.insert(strings.TrimPrefix(prefix, word))
+
+			return
+		}
+
+		slices.Sort(candidates)
+		fmt.Fprintf(editor.output, "\n%s\n", strings.Join(candidates, "  "))
+	}
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+
+	for _, value := range values[1:] {
+		for !strings.HasPrefix(value, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+
+	return prefix
+}
+
+func (state *lineState) setLine(line string) {
+	state.line = []rune(line)
+	state.cursor = len(state.line)
+}
+
+func (state *lineState) insert(text string) {
+	runes := []rune(text)
+	state.line = slices.Insert(state.line, state.cursor, runes...)
+	state.cursor += len(runes)
+}
+
+func (state *lineState) deleteBackward() {
+	if state.cursor == 0 {
+		return
+	}
+
+	state.line = slices.Delete(state.line, state.cursor-1, state.cursor)
+	state.cursor--
+}
+
+func (state *lineState) deleteForward() {
+	if state.cursor >= len(state.line) {
+		return
+	}
+
+	state.line = slices.Delete(state.line, state.cursor, state.cursor+1)
+}
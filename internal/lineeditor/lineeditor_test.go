@@ -0,0 +1,124 @@
+package lineeditor
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func completeWords(previousWords []string, _ string) []string {
+	if len(previousWords) == 0 {
+		return []string{"check", "list-objects", "list-users"}
+	}
+
+	return []string{"document:", "user:"}
+}
+
+func TestEditorReadLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		history  []string
+		expected string
+	}{
+		{name: "plain line", input: "check a b c\r", expected: "check a b c"},
+		{name: "backspace", input: "checkk\x7f a\r", expected: "check a"},
+		{name: "insert after moving left", input: "chck\x1b[D\x1b[De\r", expected: "check"},
+		{name: "home and kill to end", input: "xcheck\x01\x1b[3~\x05 a\x01\x0b\r", expected: ""},
+		{name: "previous history entry", input: "\x1b[A\r", history: []string{"first", "second"}, expected: "second"},
+		{name: "back to the typed line", input: "typed\x1b[A\x1b[A\x1b[B\x1b[B\r", history: []string{"first"}, expected: "typed"},
+		{name: "single candidate", input: "ch\t\r", expected: "check "},
+		{name: "common prefix", input: "li\t\r", expected: "list-"},
+		{name: "no space after type", input: "check us\t\r", expected: "check user:"},
+		{name: "unknown candidate", input: "zz\t\r", expected: "zz"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			editor := newEditor(strings.NewReader(test.input), io.Discard, true, completeWords)
+			for _, line := range test.history {
+				editor.AddHistory(line)
+			}
+
+			line, err := editor.ReadLine("> ")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, line)
+		})
+	}
+}
+
+func TestEditorListsCandidates(t *testing.T) {
+	t.Parallel()
+
+	output := &strings.Builder{}
+	editor := newEditor(strings.NewReader("list-\t\r"), output, true, completeWords)
+
+	line, err := editor.ReadLine("> ")
+	require.NoError(t, err)
+	assert.Equal(t, "list-", line)
+	assert.Contains(t, output.String(), "\nlist-objects  list-users\n")
+}
+
+func TestEditorControlKeys(t *testing.T) {
+	t.Parallel()
+
+	editor := newEditor(strings.NewReader("abc\x03\x04"), io.Discard, true, nil)
+
+	_, err := editor.ReadLine("> ")
+	require.ErrorIs(t, err, ErrInterrupted)
+
+	_, err = editor.ReadLine("> ")
+	require.ErrorIs(t, err, io.EOF)
+}
+
+func TestEditorReadPlainLines(t *testing.T) {
+	t.Parallel()
+
+	editor := newEditor(strings.NewReader("first\r\nsecond"), io.Discard, false, nil)
+
+	lines := []string{}
+
+	for {
+		line, err := editor.ReadLine("> ")
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		require.NoError(t, err)
+
+		lines = append(lines, line)
+	}
+
+	assert.Equal(t, []string{"first", "second"}, lines)
+}
+
+func TestEditorHistoryFile(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "history")
+
+	editor := newEditor(strings.NewReader(""), io.Discard, false, nil)
+	require.NoError(t, editor.LoadHistory(fileName))
+
+	editor.AddHistory("check a b c")
+	editor.AddHistory("check a b c")
+	editor.AddHistory("  ")
+	editor.AddHistory("expand b c")
+	require.NoError(t, editor.SaveHistory(fileName))
+
+	reloaded := newEditor(strings.NewReader(""), io.Discard, false, nil)
+	reloaded.maxHistory = 1
+	require.NoError(t, reloaded.LoadHistory(fileName))
+
+	assert.Equal(t, []string{"check a b c", "expand b c"}, editor.History())
+	assert.Equal(t, []string{"expand b c"}, reloaded.History())
+}
@@ -0,0 +1,28 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lineeditor
+
+import "errors"
+
+var errRawModeUnsupported = errors.New("raw mode is not supported on this platform")
+
+// enableRawMode is not supported on this platform, lines are read as they come.
+func enableRawMode(int) (func(), error) {
+	return nil, errRawModeUnsupported
+}
@@ -0,0 +1,273 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localserver runs an embedded OpenFGA server backed by an in-memory datastore, and
+// serves its HTTP API so that the SDK client can be pointed at it like at any other server.
+package localserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	pb "github.com/openfga/api/proto/openfga/v1"
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	httpmiddleware "github.com/openfga/openfga/pkg/middleware/http"
+	serverErrors "github.com/openfga/openfga/pkg/server/errors"
+	"google.golang.org/grpc/status"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/fga"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+const (
+	// DefaultAddr listens on a free port of the loopback interface.
+	DefaultAddr = "127.0.0.1:0"
+	// DefaultMaxTypesPerAuthorizationModel matches the default of the OpenFGA server.
+	DefaultMaxTypesPerAuthorizationModel = 100
+
+	maxTuplesPerWrite  = 100
+	minStoreNameLength = 3
+	readHeaderTimeout  = 10 * time.Second
+	shutdownTimeout    = 5 * time.Second
+)
+
+// Config holds the configuration of the embedded server.
+type Config struct {
+	// Addr is the address the HTTP API listens on
+	Addr                          string
+	MaxTypesPerAuthorizationModel int
+}
+
+// Server is an embedded OpenFGA server. Its data is lost once it is closed.
+type Server struct {
+	stop       func()
+	httpServer *http.Server
+	listener   net.Listener
+}
+
+// Start starts the embedded server and serves its HTTP API on config.Addr. Close stops it.
+func Start(ctx context.Context, config Config) (*Server, error) {
+	fgaServer, stop, err := storetest.NewEmbeddedServer(storetest.LocalServerConfig{
+		MaxTypesPerAuthorizationModel: config.MaxTypesPerAuthorizationModel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the local server due to %w", err)
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithForwardResponseOption(httpmiddleware.HTTPResponseModifier),
+		runtime.WithErrorHandler(func(
+			ctx context.Context,
+			_ *runtime.ServeMux,
+			_ runtime.Marshaler,
+			w http.ResponseWriter,
+			r *http.Request,
+			err error,
+		) {
+			code := serverErrors.ConvertToEncodedErrorCode(status.Convert(err))
+			httpmiddleware.CustomHTTPErrorHandler(ctx, w, r, serverErrors.NewEncodedError(code, err.Error()))
+		}),
+	)
+
+	localServer := &Server{stop: stop}
+
+	if err := pb.RegisterOpenFGAServiceHandlerServer(ctx, mux, fgaServer); err != nil {
+		localServer.Close()
+
+		return nil, fmt.Errorf("failed to start the local server due to %w", err)
+	}
+
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", config.Addr)
+	if err != nil {
+		localServer.Close()
+
+		return nil, fmt.Errorf("failed to listen on %s due to %w", config.Addr, err)
+	}
+
+	localServer.listener = listener
+	localServer.httpServer = &http.Server{Handler: mux, ReadHeaderTimeout: readHeaderTimeout}
+
+	go func() {
+		_ = localServer.httpServer.Serve(listener)
+	}()
+
+	return localServer, nil
+}
+
+// APIURL is the URL of the HTTP API of the server.
+func (localServer *Server) APIURL() string {
+	return "http://" + localServer.listener.Addr().String()
+}
+
+// Close stops serving the HTTP API and stops the server.
+func (localServer *Server) Close() {
+	if localServer.httpServer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+
+		if err := localServer.httpServer.Shutdown(ctx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			_ = localServer.httpServer.Close()
+		}
+	}
+
+	localServer.stop()
+}
+
+// ClientConfig is the configuration of a client of the server, for the given store and model.
+func (localServer *Server) ClientConfig(storeID string, modelID string) fga.ClientConfig {
+	return fga.ClientConfig{
+		ApiUrl:               localServer.APIURL(),
+		StoreID:              storeID,
+		AuthorizationModelID: modelID,
+	}
+}
+
+// LoadedStore identifies the store a store file was loaded into.
+type LoadedStore struct {
+	StoreID string `json:"store_id"`
+	ModelID string `json:"model_id,omitempty"`
+}
+
+// LoadStore creates a store named after the store file, and writes the model and tuples of the
+// store file to it. The tuples of the tests are not written, they only apply to their own test.
+func (localServer *Server) LoadStore(
+	ctx context.Context,
+	storeData *storetest.StoreData,
+	format authorizationmodel.ModelFormat,
+	fileName string,
+) (*LoadedStore, error) {
+	fgaClient, err := localServer.ClientConfig("", "").GetFgaClient()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize FGA Client due to %w", err)
+	}
+
+	storeName := storeData.Name
+	if storeName == "" {
+		storeName = strings.TrimSuffix(path.Base(fileName), ".fga.yaml")
+		// Store names need at least 3 characters
+		if len(storeName) < minStoreNameLength {
+			storeName = path.Base(fileName)
+		}
+	}
+
+	store, err := fgaClient.CreateStore(ctx).Body(client.ClientCreateStoreRequest{Name: storeName}).Execute()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create store %v due to %w", storeName, err)
+	}
+
+	if err := fgaClient.SetStoreId(store.Id); err != nil {
+		return nil, fmt.Errorf("failed to set store ID: %w", err)
+	}
+
+	loadedStore := &LoadedStore{StoreID: store.Id}
+
+	if storeData.Model != "" {
+		authModel := authorizationmodel.AuthzModel{}
+
+		err := authModel.ReadModelFromStringContained(storeData.Model, format, storeData.ModelContainBase())
+		if err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		body := client.ClientWriteAuthorizationModelRequest{
+			SchemaVersion:   authModel.GetSchemaVersion(),
+			TypeDefinitions: authModel.GetTypeDefinitions(),
+			Conditions:      authModel.GetConditions(),
+		}
+
+		model, err := fgaClient.WriteAuthorizationModel(ctx).Body(body).Execute()
+		if err != nil {
+			return nil, fmt.Errorf("failed to write model due to %w", err)
+		}
+
+		loadedStore.ModelID = model.AuthorizationModelId
+	}
+
+	if err := writeTuples(ctx, fgaClient, storeData.Tuples); err != nil {
+		return nil, err
+	}
+
+	return loadedStore, nil
+}
+
+// WriteAssertions writes the assertions of the loaded store's model.
+func (localServer *Server) WriteAssertions(
+	ctx context.Context,
+	loadedStore *LoadedStore,
+	assertions []client.ClientAssertion,
+) error {
+	fgaClient, err := localServer.ClientConfig(loadedStore.StoreID, loadedStore.ModelID).GetFgaClient()
+	if err != nil {
+		return fmt.Errorf("failed to initialize FGA Client due to %w", err)
+	}
+
+	_, err = fgaClient.WriteAssertions(ctx).Body(assertions).Execute()
+	if err != nil {
+		return fmt.Errorf("failed to write assertions due to %w", err)
+	}
+
+	return nil
+}
+
+// StartWithStoreFile starts an embedded server and loads the store file into it. It returns the
+// store file, and the configuration of a client of the loaded store.
+func StartWithStoreFile(
+	ctx context.Context,
+	config Config,
+	fileName string,
+	allowExternalFiles bool,
+) (*Server, *storetest.StoreData, fga.ClientConfig, error) {
+	format, storeData, err := storetest.ReadFromFile(fileName, "", allowExternalFiles)
+	if err != nil {
+		return nil, nil, fga.ClientConfig{}, err //nolint:wrapcheck
+	}
+
+	localServer, err := Start(ctx, config)
+	if err != nil {
+		return nil, nil, fga.ClientConfig{}, err
+	}
+
+	loadedStore, err := localServer.LoadStore(ctx, storeData, format, fileName)
+	if err != nil {
+		localServer.Close()
+
+		return nil, nil, fga.ClientConfig{}, fmt.Errorf("failed to load %s due to %w", fileName, err)
+	}
+
+	return localServer, storeData, localServer.ClientConfig(loadedStore.StoreID, loadedStore.ModelID), nil
+}
+
+func writeTuples(ctx context.Context, fgaClient client.SdkClient, tuples []openfga.TupleKey) error {
+	for index := 0; index < len(tuples); index += maxTuplesPerWrite {
+		end := min(index+maxTuplesPerWrite, len(tuples))
+
+		_, err := fgaClient.Write(ctx).Body(client.ClientWriteRequest{Writes: tuples[index:end]}).Execute()
+		if err != nil {
+			return fmt.Errorf("failed to write tuples due to %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package localserver
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/storetest"
+)
+
+func TestLoadStoreAndQuery(t *testing.T) {
+	t.Parallel()
+
+	localServer, err := Start(t.Context(), Config{Addr: DefaultAddr, MaxTypesPerAuthorizationModel: 100})
+	require.NoError(t, err)
+	t.Cleanup(localServer.Close)
+
+	storeData := &storetest.StoreData{
+		Model: `model
+  schema 1.1
+type user
+type document
+  relations
+    define owner: [user]
+    define viewer: [user] or owner`,
+		Tuples: []openfga.TupleKey{
+			{User: "user:anne", Relation: "owner", Object: "document:1"},
+		},
+	}
+
+	loadedStore, err := localServer.LoadStore(t.Context(), storeData, authorizationmodel.ModelFormatDefault, "docs.fga.yaml")
+	require.NoError(t, err)
+	assert.NotEmpty(t, loadedStore.StoreID)
+	assert.NotEmpty(t, loadedStore.ModelID)
+
+	fgaClient, err := localServer.ClientConfig(loadedStore.StoreID, loadedStore.ModelID).GetFgaClient()
+	require.NoError(t, err)
+
+	store, err := fgaClient.GetStore(t.Context()).Execute()
+	require.NoError(t, err)
+	assert.Equal(t, "docs", store.GetName())
+
+	response, err := fgaClient.Check(t.Context()).Body(client.ClientCheckRequest{
+		User: "user:anne", Relation: "viewer", Object: "document:1",
+	}).Execute()
+	require.NoError(t, err)
+	assert.True(t, response.GetAllowed())
+
+	_, err = fgaClient.Check(t.Context()).Body(client.ClientCheckRequest{
+		User: "user:anne", Relation: "editor", Object: "document:1",
+	}).Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation_error")
+}
@@ -0,0 +1,262 @@
+/*
+Copyright © 2023 OpenFGA
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package profile handles named connection profiles stored in the CLI config file
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openfga/cli/internal/fga"
+)
+
+const (
+	profilesKey       = "profiles"
+	currentProfileKey = "current-profile"
+)
+
+var (
+	ErrProfileNotFound    = errors.New("profile not found")
+	ErrProfileExists      = errors.New("profile already exists")
+	ErrInvalidProfileName = errors.New("profile names may only contain letters, digits, '-' and '_'")
+
+	profileNameRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Profile holds the connection settings of a single named profile. The keys match
+// the top-level config keys, so a profile is applied exactly like a flat config.
+type Profile struct {
+	APIURL         string   `json:"api_url,omitempty"          yaml:"api-url,omitempty"`
+	StoreID        string   `json:"store_id,omitempty"         yaml:"store-id,omitempty"`
+	ModelID        string   `json:"model_id,omitempty"         yaml:"model-id,omitempty"`
+	APIToken       string   `json:"api_token,omitempty"        yaml:"api-token,omitempty"`
+	APITokenIssuer string   `json:"api_token_issuer,omitempty" yaml:"api-token-issuer,omitempty"`
+	APIAudience    string   `json:"api_audience,omitempty"     yaml:"api-audience,omitempty"`
+	APIScopes      []string `json:"api_scopes,omitempty"       yaml:"api-scopes,omitempty"`
+	ClientID       string   `json:"client_id,omitempty"        yaml:"client-id,omitempty"`
+	ClientSecret   string   `json:"client_secret,omitempty"    yaml:"client-secret,omitempty"`
+	CustomHeaders  []string `json:"custom_headers,omitempty"   yaml:"custom-headers,omitempty"`
+}
+
+// ClientConfig builds the SDK client configuration for the profile.
+func (profile Profile) ClientConfig() fga.ClientConfig {
+	return fga.ClientConfig{
+		ApiUrl:               profile.APIURL,
+		StoreID:              profile.StoreID,
+		AuthorizationModelID: profile.ModelID,
+		APIToken:             profile.APIToken,
+		APITokenIssuer:       profile.APITokenIssuer,
+		APIAudience:          profile.APIAudience,
+		APIScopes:            profile.APIScopes,
+		ClientID:             profile.ClientID,
+		ClientSecret:         profile.ClientSecret,
+		CustomHeaders:        profile.CustomHeaders,
+	}
+}
+
+// ValidateName returns an error if name cannot be used as a profile name. Dots are
+// rejected because they would be read as nested keys by the config loader.
+func ValidateName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidProfileName, name)
+	}
+
+	return nil
+}
+
+// ConfigFile is a CLI config file along with the profiles it declares. Keys other
+// than profiles and current-profile are kept as-is when the file is saved.
+type ConfigFile struct {
+	Path           string
+	CurrentProfile string
+	Profiles       map[string]Profile
+
+	raw map[string]any
+}
+
+// ReadConfigFile reads the config file at path. A missing file is not an error, it
+// is treated as an empty config so that it can be created by Save.
+func ReadConfigFile(path string) (*ConfigFile, error) {
+	configFile := &ConfigFile{
+		Path:     path,
+		Profiles: map[string]Profile{},
+		raw:      map[string]any{},
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return configFile, nil
+		}
+
+		return nil, fmt.Errorf("failed to read config file %s due to %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, &configFile.raw); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s due to %w", path, err)
+	}
+
+	if configFile.raw == nil {
+		configFile.raw = map[string]any{}
+	}
+
+	if current, ok := configFile.raw[currentProfileKey].(string); ok {
+		configFile.CurrentProfile = current
+	}
+
+	if rawProfiles, ok := configFile.raw[profilesKey]; ok {
+		// round-trip through yaml so the profile keys are decoded with the struct tags
+		profilesYaml, err := yaml.Marshal(rawProfiles)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profiles from %s due to %w", path, err)
+		}
+
+		if err := yaml.Unmarshal(profilesYaml, &configFile.Profiles); err != nil {
+			return nil, fmt.Errorf("failed to read profiles from %s due to %w", path, err)
+		}
+	}
+
+	return configFile, nil
+}
+
+// Names returns the profile names in alphabetical order.
+func (configFile *ConfigFile) Names() []string {
+	names := make([]string, 0, len(configFile.Profiles))
+	for name := range configFile.Profiles {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func (configFile *ConfigFile) Get(name string) (Profile, error) {
+	profile, ok := configFile.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	return profile, nil
+}
+
+// Add adds a new profile, failing if one with the same name already exists.
+func (configFile *ConfigFile) Add(name string, profile Profile) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	if _, ok := configFile.Profiles[name]; ok {
+		return fmt.Errorf("%w: %s", ErrProfileExists, name)
+	}
+
+	configFile.Profiles[name] = profile
+
+	return nil
+}
+
+// Remove deletes a profile. If it was the current profile, no profile is current afterwards.
+func (configFile *ConfigFile) Remove(name string) error {
+	if _, ok := configFile.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	delete(configFile.Profiles, name)
+
+	if configFile.CurrentProfile == name {
+		configFile.CurrentProfile = ""
+	}
+
+	return nil
+}
+
+// Use makes name the profile applied when no --profile flag is passed.
+func (configFile *ConfigFile) Use(name string) error {
+	if _, ok := configFile.Profiles[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	configFile.CurrentProfile = name
+
+	return nil
+}
+
+// Save writes the config file back to its path.
+func (configFile *ConfigFile) Save() error {
+	if len(configFile.Profiles) > 0 {
+		configFile.raw[profilesKey] = configFile.Profiles
+	} else {
+		delete(configFile.raw, profilesKey)
+	}
+
+	if configFile.CurrentProfile != "" {
+		configFile.raw[currentProfileKey] = configFile.CurrentProfile
+	} else {
+		delete(configFile.raw, currentProfileKey)
+	}
+
+	data, err := yaml.Marshal(configFile.raw)
+	if err != nil {
+		return fmt.Errorf("failed to marshal config file due to %w", err)
+	}
+
+	if err := os.WriteFile(configFile.Path, data, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write config file %s due to %w", configFile.Path, err)
+	}
+
+	return nil
+}
+
+// Selected returns the name of the profile to apply: the value passed to --profile,
+// then FGA_PROFILE, then the current-profile set in the config file.
+func Selected(viperInstance *viper.Viper, flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+
+	if name := viperInstance.GetString("profile"); name != "" {
+		return name
+	}
+
+	return viperInstance.GetString(currentProfileKey)
+}
+
+// Apply merges the settings of the named profile into the config read by
+// viperInstance, so they are picked up like top-level config keys. Flags and
+// environment variables still take precedence over them.
+func Apply(viperInstance *viper.Viper, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	key := profilesKey + "." + name
+	if !viperInstance.IsSet(key) {
+		return fmt.Errorf("%w: %s", ErrProfileNotFound, name)
+	}
+
+	if err := viperInstance.MergeConfigMap(viperInstance.GetStringMap(key)); err != nil {
+		return fmt.Errorf("failed to apply profile %s due to %w", name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConfig = `api-url: http://localhost:8080
+store-id: 01H0H015178Y2V4CX10C2KGHF4
+current-profile: dev
+profiles:
+  dev:
+    api-url: http://localhost:8081
+    store-id: 01H0H015178Y2V4CX10C2KGHF5
+    custom-headers:
+      - "X-Env: dev"
+  prod:
+    api-url: https://api.fga.example
+    client-id: client
+    client-secret: secret
+`
+
+func writeConfig(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), ".fga.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testConfig), 0o600))
+
+	return path
+}
+
+func TestReadConfigFile(t *testing.T) {
+	t.Parallel()
+
+	configFile, err := ReadConfigFile(writeConfig(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "dev", configFile.CurrentProfile)
+	assert.Equal(t, []string{"dev", "prod"}, configFile.Names())
+
+	prod, err := configFile.Get("prod")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.fga.example", prod.ClientConfig().ApiUrl)
+	assert.Equal(t, "client", prod.ClientConfig().ClientID)
+
+	_, err = configFile.Get("staging")
+	require.ErrorIs(t, err, ErrProfileNotFound)
+}
+
+func TestReadConfigFileMissing(t *testing.T) {
+	t.Parallel()
+
+	configFile, err := ReadConfigFile(filepath.Join(t.TempDir(), ".fga.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, configFile.Profiles)
+	assert.Empty(t, configFile.CurrentProfile)
+}
+
+func TestConfigFileSaveKeepsOtherKeys(t *testing.T) {
+	t.Parallel()
+
+	path := writeConfig(t)
+
+	configFile, err := ReadConfigFile(path)
+	require.NoError(t, err)
+
+	require.NoError(t, configFile.Add("staging", Profile{APIURL: "https://staging.fga.example"}))
+	require.ErrorIs(t, configFile.Add("staging", Profile{}), ErrProfileExists)
+	require.ErrorIs(t, configFile.Add("with.dot", Profile{}), ErrInvalidProfileName)
+	require.NoError(t, configFile.Use("staging"))
+	require.NoError(t, configFile.Remove("dev"))
+	require.ErrorIs(t, configFile.Remove("dev"), ErrProfileNotFound)
+	require.NoError(t, configFile.Save())
+
+	reread, err := ReadConfigFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "staging", reread.CurrentProfile)
+	assert.Equal(t, []string{"prod", "staging"}, reread.Names())
+	assert.Equal(t, "https://staging.fga.example", reread.Profiles["staging"].APIURL)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "store-id: 01H0H015178Y2V4CX10C2KGHF4")
+}
+
+func TestRemoveCurrentProfile(t *testing.T) {
+	t.Parallel()
+
+	configFile, err := ReadConfigFile(writeConfig(t))
+	require.NoError(t, err)
+
+	require.NoError(t, configFile.Remove("dev"))
+	assert.Empty(t, configFile.CurrentProfile)
+}
+
+func TestSelectedAndApply(t *testing.T) {
+	t.Parallel()
+
+	testcases := []struct {
+		name            string
+		flagValue       string
+		expectedProfile string
+		expectedURL     string
+		expectedHeaders []string
+		err             error
+	}{
+		{
+			name:            "current profile is applied by default",
+			expectedProfile: "dev",
+			expectedURL:     "http://localhost:8081",
+			expectedHeaders: []string{"X-Env: dev"},
+		},
+		{
+			name:            "flag overrides the current profile",
+			flagValue:       "prod",
+			expectedProfile: "prod",
+			expectedURL:     "https://api.fga.example",
+		},
+		{
+			name:            "unknown profile returns an error",
+			flagValue:       "staging",
+			expectedProfile: "staging",
+			err:             ErrProfileNotFound,
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			viperInstance := viper.New()
+			viperInstance.SetConfigType("yaml")
+			require.NoError(t, viperInstance.ReadConfig(strings.NewReader(testConfig)))
+
+			name := Selected(viperInstance, test.flagValue)
+			assert.Equal(t, test.expectedProfile, name)
+
+			err := Apply(viperInstance, name)
+			if test.err != nil {
+				require.ErrorIs(t, err, test.err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedURL, viperInstance.GetString("api-url"))
+			assert.Equal(t, test.expectedHeaders, viperInstance.GetStringSlice("custom-headers"))
+		})
+	}
+}
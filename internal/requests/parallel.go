@@ -0,0 +1,61 @@
+package requests
+
+import (
+	"sync"
+)
+
+// RunInParallel calls run for each index from 0 to count-1, with at most parallel calls
+// running at a time. Callers store results by index, so they keep the order of the input
+// regardless of the order in which the calls complete. Once a call fails, no new calls are
+// started, and the error of the lowest failing index is returned.
+func RunInParallel(count int, parallel int, run func(index int) error) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	var (
+		semaphore = make(chan struct{}, parallel)
+		waitGroup sync.WaitGroup
+		mutex     sync.Mutex
+		errs      = make([]error, count)
+		failed    bool
+	)
+
+	for index := range count {
+		semaphore <- struct{}{}
+
+		mutex.Lock()
+		stop := failed
+		mutex.Unlock()
+
+		if stop {
+			<-semaphore
+
+			break
+		}
+
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+			defer func() { <-semaphore }()
+
+			if err := run(index); err != nil {
+				mutex.Lock()
+				errs[index] = err
+				failed = true
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	waitGroup.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package requests
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errParallelTest = errors.New("failed")
+
+func TestRunInParallel(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning atomic.Int32
+
+	results := make([]int, 20)
+
+	err := RunInParallel(len(results), 3, func(index int) error {
+		current := running.Add(1)
+		defer running.Add(-1)
+
+		for {
+			seen := maxRunning.Load()
+			if current <= seen || maxRunning.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+
+		results[index] = index * index
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	for index, result := range results {
+		assert.Equal(t, index*index, result)
+	}
+
+	assert.LessOrEqual(t, maxRunning.Load(), int32(3))
+}
+
+func TestRunInParallelReturnsFirstError(t *testing.T) {
+	t.Parallel()
+
+	err := RunInParallel(10, 4, func(index int) error {
+		if index >= 2 {
+			return fmt.Errorf("%w: %d", errParallelTest, index)
+		}
+
+		return nil
+	})
+	require.ErrorIs(t, err, errParallelTest)
+	assert.EqualError(t, err, "failed: 2")
+}
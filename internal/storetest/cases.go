@@ -0,0 +1,171 @@
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ErrUndefinedCaseVariable is returned when a check uses a {{name}} placeholder that a case does not set.
+var ErrUndefinedCaseVariable = errors.New("uses a variable that the case does not set")
+
+// casePlaceholderPattern matches the {{name}} placeholders that the variables of a case replace.
+var casePlaceholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// expandCheckCases returns a check for each case of the check test, with the placeholders replaced by
+// the variables of the case, and the context, assertions and expectations of the case added to the
+// ones of the check. A check test without cases is returned as is.
+func expandCheckCases(checkTest ModelTestCheck) []ModelTestCheck {
+	if len(checkTest.Cases) == 0 {
+		return []ModelTestCheck{checkTest}
+	}
+
+	checks := make([]ModelTestCheck, 0, len(checkTest.Cases))
+
+	for _, checkCase := range checkTest.Cases {
+		check := ModelTestCheck{
+			User:         expandCaseVars(checkTest.User, checkCase.Vars),
+			Users:        expandCaseVarsAll(checkTest.Users, checkCase.Vars),
+			Object:       expandCaseVars(checkTest.Object, checkCase.Vars),
+			Objects:      expandCaseVarsAll(checkTest.Objects, checkCase.Vars),
+			Context:      mergeCaseContext(checkTest.Context, checkCase.Context, checkCase.Vars),
+			Assertions:   mergeCaseMaps(checkTest.Assertions, checkCase.Assertions),
+			Expectations: mergeCaseMaps(checkTest.Expectations, checkCase.Expectations),
+		}
+
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+func expandAllCheckCases(checkTests []ModelTestCheck) []ModelTestCheck {
+	checks := make([]ModelTestCheck, 0, len(checkTests))
+	for _, checkTest := range checkTests {
+		checks = append(checks, expandCheckCases(checkTest)...)
+	}
+
+	return checks
+}
+
+func expandCaseVars(value string, vars map[string]string) string {
+	return casePlaceholderPattern.ReplaceAllStringFunc(value, func(placeholder string) string {
+		name := casePlaceholderPattern.FindStringSubmatch(placeholder)[1]
+		if varValue, ok := vars[name]; ok {
+			return varValue
+		}
+
+		return placeholder
+	})
+}
+
+func expandCaseVarsAll(values []string, vars map[string]string) []string {
+	if values == nil {
+		return nil
+	}
+
+	expanded := make([]string, 0, len(values))
+	for _, value := range values {
+		expanded = append(expanded, expandCaseVars(value, vars))
+	}
+
+	return expanded
+}
+
+// mergeCaseContext returns the context of the check with the context of the case set over it, and the
+// placeholders of its string values replaced.
+func mergeCaseContext(
+	checkContext *map[string]any,
+	caseContext *map[string]any,
+	vars map[string]string,
+) *map[string]any {
+	if checkContext == nil && caseContext == nil {
+		return nil
+	}
+
+	context := map[string]any{}
+
+	for _, values := range []*map[string]any{checkContext, caseContext} {
+		if values != nil {
+			maps.Copy(context, *values)
+		}
+	}
+
+	for key, value := range context {
+		context[key] = expandCaseContextValue(value, vars)
+	}
+
+	return &context
+}
+
+func expandCaseContextValue(value any, vars map[string]string) any {
+	switch typedValue := value.(type) {
+	case string:
+		return expandCaseVars(typedValue, vars)
+	case []any:
+		expanded := make([]any, 0, len(typedValue))
+		for _, item := range typedValue {
+			expanded = append(expanded, expandCaseContextValue(item, vars))
+		}
+
+		return expanded
+	case map[string]any:
+		expanded := make(map[string]any, len(typedValue))
+		for key, item := range typedValue {
+			expanded[key] = expandCaseContextValue(item, vars)
+		}
+
+		return expanded
+	default:
+		return value
+	}
+}
+
+// mergeCaseMaps returns the assertions or expectations of the check, with the ones of the case
+// replacing them relation by relation.
+func mergeCaseMaps[V any](checkValues map[string]V, caseValues map[string]V) map[string]V {
+	if checkValues == nil && caseValues == nil {
+		return nil
+	}
+
+	merged := maps.Clone(checkValues)
+	if merged == nil {
+		merged = map[string]V{}
+	}
+
+	maps.Copy(merged, caseValues)
+
+	return merged
+}
+
+// validateCheckCases returns an error for each variable that a case of the check leaves unset.
+func validateCheckCases(testName string, index int, checkTest ModelTestCheck) error {
+	if len(checkTest.Cases) == 0 {
+		return nil
+	}
+
+	var errs error
+
+	for caseIndex, check := range expandCheckCases(checkTest) {
+		values := append(append([]string{check.User, check.Object}, check.Users...), check.Objects...)
+
+		if check.Context != nil {
+			values = append(values, fmt.Sprint(*check.Context))
+		}
+
+		undefined := []string{}
+
+		for _, match := range casePlaceholderPattern.FindAllStringSubmatch(strings.Join(values, " "), -1) {
+			if !slices.Contains(undefined, match[1]) {
+				undefined = append(undefined, match[1])
+				errs = errors.Join(errs, fmt.Errorf("test %s check %d case %d: %w %q",
+					testName, index, caseIndex, ErrUndefinedCaseVariable, match[1]))
+			}
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,117 @@
+package storetest
+
+import (
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+const casesCheck = `
+user: user:{{ name }}
+object: document:{{id}}
+context:
+  owner: "{{name}}"
+assertions:
+  viewer: false
+cases:
+  - vars: {name: anne, id: 1}
+    assertions:
+      viewer: true
+  - vars: {name: bob, id: 2}
+    context:
+      extra: true
+    assertions:
+      editor: true
+`
+
+func TestExpandCheckCases(t *testing.T) {
+	t.Parallel()
+
+	var check ModelTestCheck
+	require.NoError(t, yaml.Unmarshal([]byte(casesCheck), &check))
+
+	checks := expandCheckCases(check)
+	require.Len(t, checks, 2)
+
+	assert.Equal(t, ModelTestCheck{
+		User:       "user:anne",
+		Object:     "document:1",
+		Context:    &map[string]any{"owner": "anne"},
+		Assertions: map[string]bool{"viewer": true},
+	}, checks[0])
+	assert.Equal(t, ModelTestCheck{
+		User:       "user:bob",
+		Object:     "document:2",
+		Context:    &map[string]any{"owner": "bob", "extra": true},
+		Assertions: map[string]bool{"viewer": false, "editor": true},
+	}, checks[1])
+
+	assert.Equal(t, []ModelTestCheck{{User: "user:anne"}}, expandCheckCases(ModelTestCheck{User: "user:anne"}))
+	assert.Len(t, GetCheckAssertions([]ModelTestCheck{check}), 3)
+}
+
+func TestValidateCheckCases(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{Tests: []ModelTest{{
+		Name: "cases",
+		Check: []ModelTestCheck{{
+			User:       "user:anne",
+			Object:     "document:{{id}}",
+			Assertions: map[string]bool{"viewer": true},
+			Cases: []ModelTestCheckCase{
+				{Vars: map[string]string{"id": "1"}},
+				{Vars: map[string]string{"name": "2"}},
+			},
+		}},
+	}}}
+
+	err := storeData.Validate()
+	require.ErrorContains(t, err, "test cases check 0 case 1: "+ErrUndefinedCaseVariable.Error()+` "id"`)
+	assert.NotContains(t, err.Error(), "case 0")
+}
+
+func TestRunTestsWithCases(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{
+		Model: `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]`,
+		Tuples: []client.ClientContextualTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			{User: "user:anne", Relation: "viewer", Object: "document:3"},
+		},
+		Tests: []ModelTest{{
+			Name: "cases",
+			Check: []ModelTestCheck{{
+				User:       "user:anne",
+				Object:     "document:{{id}}",
+				Assertions: map[string]bool{"viewer": true},
+				Cases: []ModelTestCheckCase{
+					{Vars: map[string]string{"id": "1"}},
+					{Vars: map[string]string{"id": "2"}, Assertions: map[string]bool{"viewer": false}},
+					{Vars: map[string]string{"id": "3"}},
+				},
+			}},
+		}},
+	}
+
+	results, err := RunTests(t.Context(), nil, storeData, authorizationmodel.ModelFormatFGA,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	require.Len(t, results.Results[0].CheckResults, 3)
+	assert.Equal(t, "document:2", results.Results[0].CheckResults[1].Request.Object)
+	assert.True(t, results.IsPassing(), results.FriendlyDisplay())
+}
@@ -0,0 +1,115 @@
+package storetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+var (
+	// ErrUnknownFixture is returned when a test uses a fixture that the store file does not define.
+	ErrUnknownFixture = errors.New("uses an undefined fixture")
+	// ErrExtendsCycle is returned when a store file extends itself, directly or through other store files.
+	ErrExtendsCycle = errors.New("store file extends itself")
+	// ErrRemovedTupleNotFound is returned when a test removes a tuple that is not a global tuple.
+	ErrRemovedTupleNotFound = errors.New("removes a tuple that is not in the global tuples")
+)
+
+// loadExtends reads the store file that the store extends, and includes its tuples and fixtures,
+// along with its model when the store has none. Its tests are not included, so that they do not run
+// once for each store file extending it. extending holds the absolute paths of the store files being
+// read, to detect cycles.
+func (storeData *StoreData) loadExtends(
+	basePath string,
+	allowExternalFiles bool,
+	format authorizationmodel.ModelFormat,
+	extending []string,
+) (authorizationmodel.ModelFormat, error) {
+	resolved, contents, err := readRef(basePath, storeData.Extends, allowExternalFiles)
+	if err != nil {
+		return format, fmt.Errorf("failed to read extended store file %s due to %w", storeData.Extends, err)
+	}
+
+	absResolved, err := filepath.Abs(resolved)
+	if err != nil {
+		return format, fmt.Errorf("failed to resolve extended store file %s due to %w", storeData.Extends, err)
+	}
+
+	if slices.Contains(extending, absResolved) {
+		return format, fmt.Errorf("%w: %s", ErrExtendsCycle, storeData.Extends)
+	}
+
+	baseFormat, base, err := readStoreFile(
+		storeData.Extends, resolved, bytes.NewReader(contents), allowExternalFiles, extending,
+	)
+	if err != nil {
+		return format, fmt.Errorf("failed to load extended store file %s due to %w", storeData.Extends, err)
+	}
+
+	if storeData.Model == "" {
+		storeData.Model = base.Model
+		format = baseFormat
+	}
+
+	storeData.Tuples = append(append([]client.ClientContextualTupleKey{}, base.Tuples...), storeData.Tuples...)
+
+	if len(base.Fixtures) > 0 {
+		// fixtures of the extending store replace the fixtures of the same name
+		fixtures := maps.Clone(base.Fixtures)
+		maps.Copy(fixtures, storeData.Fixtures)
+		storeData.Fixtures = fixtures
+	}
+
+	storeData.extendedFiles = base.ReferencedFiles(resolved, baseFormat)
+
+	return format, nil
+}
+
+// loadFixtures adds the tuples of the fixtures each test uses to the tuples of the test.
+func (storeData *StoreData) loadFixtures() error {
+	var errs error
+
+	for testIndex, testCase := range storeData.Tests {
+		for _, name := range testCase.Use {
+			fixture, ok := storeData.Fixtures[name]
+			if !ok {
+				errs = errors.Join(errs, fmt.Errorf("test %s: %w %q", testCase.Name, ErrUnknownFixture, name))
+
+				continue
+			}
+
+			storeData.Tests[testIndex].Tuples = append(storeData.Tests[testIndex].Tuples, fixture...)
+		}
+	}
+
+	return errs
+}
+
+// testTuples returns the tuples the test runs with: the global tuples, except the ones the test
+// removes, followed by the tuples of the test.
+func testTuples(test ModelTest, globalTuples []client.ClientContextualTupleKey) []client.ClientContextualTupleKey {
+	tuples := make([]client.ClientContextualTupleKey, 0, len(globalTuples)+len(test.Tuples))
+
+	for _, tuple := range globalTuples {
+		if !containsTupleKey(test.TuplesRemove, tuple) {
+			tuples = append(tuples, tuple)
+		}
+	}
+
+	return append(tuples, test.Tuples...)
+}
+
+// containsTupleKey reports whether tuples has a tuple with the user, relation and object of tuple,
+// whatever their conditions.
+func containsTupleKey(tuples []client.ClientContextualTupleKey, tuple client.ClientContextualTupleKey) bool {
+	return slices.ContainsFunc(tuples, func(existing client.ClientContextualTupleKey) bool {
+		return existing.User == tuple.User && existing.Relation == tuple.Relation && existing.Object == tuple.Object
+	})
+}
@@ -0,0 +1,170 @@
+package storetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const composeBaseStore = `name: base
+model: |
+  model
+    schema 1.1
+
+  type user
+
+  type document
+    relations
+      define owner: [user]
+      define viewer: [user] or owner
+tuples:
+  - user: user:anne
+    relation: owner
+    object: document:1
+fixtures:
+  bob-viewer:
+    - user: user:bob
+      relation: viewer
+      object: document:1
+tests:
+  - name: base test
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          owner: true
+`
+
+func TestReadFromFileComposition(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeTempFile(t, tempDir, "base.fga.yaml", composeBaseStore)
+	storeFile := writeTempFile(t, tempDir, "store.fga.yaml", `name: store
+extends: base.fga.yaml
+tuples:
+  - user: user:carl
+    relation: owner
+    object: document:1
+fixtures:
+  dan-viewer:
+    - user: user:dan
+      relation: viewer
+      object: document:1
+tests:
+  - name: without anne
+    use: [bob-viewer, dan-viewer]
+    tuples_remove:
+      - user: user:anne
+        relation: owner
+        object: document:1
+    check:
+      - users: [user:bob, user:carl, user:dan]
+        object: document:1
+        assertions:
+          viewer: true
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: false
+  - name: with anne
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: true
+      - user: user:bob
+        object: document:1
+        assertions:
+          viewer: false
+`)
+
+	format, storeData, err := ReadFromFile(storeFile, "", false)
+	require.NoError(t, err)
+
+	assert.Len(t, storeData.Tuples, 2)
+	assert.Len(t, storeData.Tests, 2, "the tests of the extended store are not included")
+	assert.Len(t, storeData.Tests[0].Tuples, 2)
+	assert.Contains(t, storeData.ReferencedFiles(storeFile, format), filepath.Join(tempDir, "base.fga.yaml"))
+
+	results, err := RunTests(t.Context(), nil, storeData, format,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1})
+	require.NoError(t, err)
+	assert.True(t, results.IsPassing(), results.FriendlyDisplay())
+}
+
+func TestReadFromFileCompositionErrors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		store       string
+		expectedErr error
+	}{
+		{
+			name: "unknown fixture",
+			store: `name: store
+extends: base.fga.yaml
+tests:
+  - name: test
+    use: [missing]
+    check: []
+`,
+			expectedErr: ErrUnknownFixture,
+		},
+		{
+			name: "removed tuple not found",
+			store: `name: store
+extends: base.fga.yaml
+tests:
+  - name: test
+    tuples_remove:
+      - user: user:bob
+        relation: owner
+        object: document:1
+    check: []
+`,
+			expectedErr: ErrRemovedTupleNotFound,
+		},
+		{
+			name: "extends itself",
+			store: `name: store
+extends: store.fga.yaml
+tests: []
+`,
+			expectedErr: ErrExtendsCycle,
+		},
+	}
+
+	for _, testCase := range cases {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			tempDir := t.TempDir()
+			writeTempFile(t, tempDir, "base.fga.yaml", composeBaseStore)
+			storeFile := writeTempFile(t, tempDir, "store.fga.yaml", testCase.store)
+
+			_, _, err := ReadFromFile(storeFile, "", false)
+			require.ErrorContains(t, err, testCase.expectedErr.Error())
+		})
+	}
+}
+
+func TestReadFromFileExtendsIsContained(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeTempFile(t, tempDir, "base.fga.yaml", composeBaseStore)
+	require.NoError(t, os.Mkdir(filepath.Join(tempDir, "sub"), 0o700))
+	storeFile := writeTempFile(t, filepath.Join(tempDir, "sub"), "store.fga.yaml", "name: store\nextends: ../base.fga.yaml\n")
+
+	_, _, err := ReadFromFile(storeFile, "", false)
+	require.Error(t, err)
+
+	_, storeData, err := ReadFromFile(storeFile, "", true)
+	require.NoError(t, err)
+	assert.Len(t, storeData.Tuples, 1)
+}
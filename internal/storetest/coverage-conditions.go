@@ -0,0 +1,194 @@
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	pb "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/pkg/storage"
+	"github.com/openfga/openfga/pkg/typesystem"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+type (
+	conditionRecorderKey struct{}
+	conditionQueryKey    struct{}
+)
+
+// conditionRecorder counts the outcomes of the conditions of the tuples that the checks and list
+// calls of the tests read, each evaluated with the context of the call, as the server does.
+type conditionRecorder struct {
+	typeSystem *typesystem.TypeSystem
+	coverage   *Coverage
+	mutex      sync.Mutex
+}
+
+func newConditionRecorder(model *authorizationmodel.AuthzModel, coverage *Coverage) (*conditionRecorder, error) {
+	typeSystem, err := typesystem.New(model.GetProtoModel())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the conditions of the model due to %w", err)
+	}
+
+	return &conditionRecorder{typeSystem: typeSystem, coverage: coverage}, nil
+}
+
+// conditionQuery is a check or list call run while the outcomes of conditions are recorded. A
+// tuple that is read more than once to answer the call is counted once.
+type conditionQuery struct {
+	recorder *conditionRecorder
+	context  *structpb.Struct
+	mutex    sync.Mutex
+	read     map[string]bool
+}
+
+// withConditionRecorder records the outcomes of the conditions evaluated by the calls run with
+// the returned context to the recorder.
+func withConditionRecorder(ctx context.Context, recorder *conditionRecorder) context.Context {
+	if recorder == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, conditionRecorderKey{}, recorder)
+}
+
+// withConditionQuery returns the context to run a call with, whose context is queryContext. It is
+// ctx itself unless condition outcomes are recorded.
+func withConditionQuery(ctx context.Context, queryContext *structpb.Struct) context.Context {
+	recorder, ok := ctx.Value(conditionRecorderKey{}).(*conditionRecorder)
+	if !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, conditionQueryKey{}, &conditionQuery{
+		recorder: recorder,
+		context:  queryContext,
+		read:     map[string]bool{},
+	})
+}
+
+// recordTupleCondition evaluates the condition of a tuple read to answer the call of ctx.
+// Evaluations that fail, e.g. because the context is missing a parameter, are not counted.
+func recordTupleCondition(ctx context.Context, tuple *pb.Tuple) {
+	tupleKey := tuple.GetKey()
+
+	name := tupleKey.GetCondition().GetName()
+	if name == "" {
+		return
+	}
+
+	query, ok := ctx.Value(conditionQueryKey{}).(*conditionQuery)
+	if !ok {
+		return
+	}
+
+	key := tupleKey.GetObject() + "#" + tupleKey.GetRelation() + "@" + tupleKey.GetUser()
+
+	query.mutex.Lock()
+	read := query.read[key]
+	query.read[key] = true
+	query.mutex.Unlock()
+
+	if read {
+		return
+	}
+
+	condition, ok := query.recorder.typeSystem.GetCondition(name)
+	if !ok {
+		return
+	}
+
+	// as in the server, the context of the tuple takes precedence over the context of the call
+	contextFields := []map[string]*structpb.Value{query.context.GetFields()}
+	if tupleContext := tupleKey.GetCondition().GetContext(); tupleContext != nil {
+		contextFields = append(contextFields, tupleContext.GetFields())
+	}
+
+	result, err := condition.Evaluate(ctx, contextFields...)
+	if err != nil || len(result.MissingParameters) > 0 {
+		return
+	}
+
+	query.recorder.mutex.Lock()
+	query.recorder.coverage.addConditionOutcome(name, result.ConditionMet)
+	query.recorder.mutex.Unlock()
+}
+
+// conditionRecordingDatastore passes the tuples the server reads to recordTupleCondition.
+type conditionRecordingDatastore struct {
+	storage.OpenFGADatastore
+}
+
+func (datastore conditionRecordingDatastore) Read(
+	ctx context.Context,
+	store string,
+	filter storage.ReadFilter,
+	options storage.ReadOptions,
+) (storage.TupleIterator, error) {
+	iterator, err := datastore.OpenFGADatastore.Read(ctx, store, filter, options)
+
+	return newConditionRecordingIterator(ctx, iterator), err //nolint:wrapcheck
+}
+
+func (datastore conditionRecordingDatastore) ReadUserTuple(
+	ctx context.Context,
+	store string,
+	filter storage.ReadUserTupleFilter,
+	options storage.ReadUserTupleOptions,
+) (*pb.Tuple, error) {
+	tuple, err := datastore.OpenFGADatastore.ReadUserTuple(ctx, store, filter, options)
+	if err == nil {
+		recordTupleCondition(ctx, tuple)
+	}
+
+	return tuple, err //nolint:wrapcheck
+}
+
+func (datastore conditionRecordingDatastore) ReadUsersetTuples(
+	ctx context.Context,
+	store string,
+	filter storage.ReadUsersetTuplesFilter,
+	options storage.ReadUsersetTuplesOptions,
+) (storage.TupleIterator, error) {
+	iterator, err := datastore.OpenFGADatastore.ReadUsersetTuples(ctx, store, filter, options)
+
+	return newConditionRecordingIterator(ctx, iterator), err //nolint:wrapcheck
+}
+
+func (datastore conditionRecordingDatastore) ReadStartingWithUser(
+	ctx context.Context,
+	store string,
+	filter storage.ReadStartingWithUserFilter,
+	options storage.ReadStartingWithUserOptions,
+) (storage.TupleIterator, error) {
+	iterator, err := datastore.OpenFGADatastore.ReadStartingWithUser(ctx, store, filter, options)
+
+	return newConditionRecordingIterator(ctx, iterator), err //nolint:wrapcheck
+}
+
+// conditionRecordingIterator passes the tuples it returns to recordTupleCondition, with the
+// context of the read that created it.
+type conditionRecordingIterator struct {
+	storage.TupleIterator
+
+	ctx context.Context //nolint:containedctx
+}
+
+func newConditionRecordingIterator(ctx context.Context, iterator storage.TupleIterator) storage.TupleIterator {
+	if iterator == nil || ctx.Value(conditionQueryKey{}) == nil {
+		return iterator
+	}
+
+	return &conditionRecordingIterator{TupleIterator: iterator, ctx: ctx}
+}
+
+func (iterator *conditionRecordingIterator) Next(ctx context.Context) (*pb.Tuple, error) {
+	tuple, err := iterator.TupleIterator.Next(ctx)
+	if err == nil {
+		recordTupleCondition(iterator.ctx, tuple)
+	}
+
+	return tuple, err //nolint:wrapcheck
+}
@@ -0,0 +1,308 @@
+package storetest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+const percent = 100
+
+// RelationCoverage counts the check, list_objects and list_users assertions made on a relation.
+type RelationCoverage struct {
+	Type       string `json:"type"`
+	Relation   string `json:"relation"`
+	Assertions int    `json:"assertions"`
+}
+
+// ConditionCoverage counts how many times a condition evaluated to true and to false.
+type ConditionCoverage struct {
+	Name       string `json:"name"`
+	TrueCount  int    `json:"true_count"`
+	FalseCount int    `json:"false_count"`
+}
+
+// Coverage holds which relations of a model are exercised by tests, and which outcomes of
+// its conditions. Condition outcomes are only collected for tests run against a local model,
+// from the conditional tuples that the checks and list calls of the tests evaluate.
+type Coverage struct {
+	Relations  []RelationCoverage  `json:"relations"`
+	Conditions []ConditionCoverage `json:"conditions"`
+}
+
+// CoverageSummary holds the number of covered and total relations and condition outcomes.
+// Each condition has two outcomes, true and false.
+type CoverageSummary struct {
+	RelationsCovered         int     `json:"relations_covered"`
+	RelationsTotal           int     `json:"relations_total"`
+	ConditionOutcomesCovered int     `json:"condition_outcomes_covered"`
+	ConditionOutcomesTotal   int     `json:"condition_outcomes_total"`
+	Percentage               float64 `json:"percentage"`
+}
+
+// newCoverage lists the relations and conditions of the model, none of them covered yet.
+func newCoverage(model *authorizationmodel.AuthzModel) *Coverage {
+	coverage := &Coverage{Relations: []RelationCoverage{}, Conditions: []ConditionCoverage{}}
+
+	for _, typeDef := range model.GetTypeDefinitions() {
+		for relation := range typeDef.GetRelations() {
+			coverage.Relations = append(coverage.Relations, RelationCoverage{Type: typeDef.Type, Relation: relation})
+		}
+	}
+
+	for name := range *model.GetConditions() {
+		coverage.Conditions = append(coverage.Conditions, ConditionCoverage{Name: name})
+	}
+
+	coverage.sort()
+
+	return coverage
+}
+
+func (coverage *Coverage) sort() {
+	sort.Slice(coverage.Relations, func(i, j int) bool {
+		if coverage.Relations[i].Type != coverage.Relations[j].Type {
+			return coverage.Relations[i].Type < coverage.Relations[j].Type
+		}
+
+		return coverage.Relations[i].Relation < coverage.Relations[j].Relation
+	})
+
+	sort.Slice(coverage.Conditions, func(i, j int) bool {
+		return coverage.Conditions[i].Name < coverage.Conditions[j].Name
+	})
+}
+
+func (coverage *Coverage) addAssertion(objectType string, relation string) {
+	for index := range coverage.Relations {
+		if coverage.Relations[index].Type == objectType && coverage.Relations[index].Relation == relation {
+			coverage.Relations[index].Assertions++
+
+			return
+		}
+	}
+}
+
+func (coverage *Coverage) addConditionOutcome(name string, outcome bool) {
+	for index := range coverage.Conditions {
+		if coverage.Conditions[index].Name != name {
+			continue
+		}
+
+		if outcome {
+			coverage.Conditions[index].TrueCount++
+		} else {
+			coverage.Conditions[index].FalseCount++
+		}
+
+		return
+	}
+}
+
+// addResults counts the assertions of the results against the relations they were made on.
+func (coverage *Coverage) addResults(results []TestResult) {
+	for _, result := range results {
+		for _, checkResult := range result.CheckResults {
+			objectType, _, _ := strings.Cut(checkResult.Request.Object, ":")
+			coverage.addAssertion(objectType, checkResult.Request.Relation)
+		}
+
+		for _, listObjectsResult := range result.ListObjectsResults {
+			coverage.addAssertion(listObjectsResult.Request.Type, listObjectsResult.Request.Relation)
+		}
+
+		for _, listUsersResult := range result.ListUsersResults {
+			coverage.addAssertion(listUsersResult.Request.Object.Type, listUsersResult.Request.Relation)
+		}
+	}
+}
+
+// Merge adds the counts of other to the coverage. Relations and conditions found in
+// only one of the two, such as when test files use different models, are kept.
+func (coverage *Coverage) Merge(other *Coverage) {
+	if other == nil {
+		return
+	}
+
+	for _, relation := range other.Relations {
+		index := -1
+
+		for i := range coverage.Relations {
+			if coverage.Relations[i].Type == relation.Type && coverage.Relations[i].Relation == relation.Relation {
+				index = i
+
+				break
+			}
+		}
+
+		if index < 0 {
+			coverage.Relations = append(coverage.Relations, relation)
+		} else {
+			coverage.Relations[index].Assertions += relation.Assertions
+		}
+	}
+
+	for _, condition := range other.Conditions {
+		index := -1
+
+		for i := range coverage.Conditions {
+			if coverage.Conditions[i].Name == condition.Name {
+				index = i
+
+				break
+			}
+		}
+
+		if index < 0 {
+			coverage.Conditions = append(coverage.Conditions, condition)
+		} else {
+			coverage.Conditions[index].TrueCount += condition.TrueCount
+			coverage.Conditions[index].FalseCount += condition.FalseCount
+		}
+	}
+
+	coverage.sort()
+}
+
+// Summary counts the covered relations and condition outcomes.
+func (coverage *Coverage) Summary() CoverageSummary {
+	summary := CoverageSummary{
+		RelationsTotal:         len(coverage.Relations),
+		ConditionOutcomesTotal: 2 * len(coverage.Conditions), //nolint:mnd
+	}
+
+	for _, relation := range coverage.Relations {
+		if relation.Assertions > 0 {
+			summary.RelationsCovered++
+		}
+	}
+
+	for _, condition := range coverage.Conditions {
+		if condition.TrueCount > 0 {
+			summary.ConditionOutcomesCovered++
+		}
+
+		if condition.FalseCount > 0 {
+			summary.ConditionOutcomesCovered++
+		}
+	}
+
+	summary.Percentage = coveragePercentage(
+		summary.RelationsCovered+summary.ConditionOutcomesCovered,
+		summary.RelationsTotal+summary.ConditionOutcomesTotal)
+
+	return summary
+}
+
+func coveragePercentage(covered int, total int) float64 {
+	if total == 0 {
+		return percent
+	}
+
+	return float64(covered) * percent / float64(total)
+}
+
+func yesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+
+	return "no"
+}
+
+// FriendlyDisplay renders the coverage summary followed by a table of relations and conditions.
+func (coverage *Coverage) FriendlyDisplay() string {
+	summary := coverage.Summary()
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "# Coverage #\nRelations %d/%d covered (%.1f%%)",
+		summary.RelationsCovered, summary.RelationsTotal,
+		coveragePercentage(summary.RelationsCovered, summary.RelationsTotal))
+
+	if summary.ConditionOutcomesTotal > 0 {
+		fmt.Fprintf(&builder, "\nCondition outcomes %d/%d covered (%.1f%%)",
+			summary.ConditionOutcomesCovered, summary.ConditionOutcomesTotal,
+			coveragePercentage(summary.ConditionOutcomesCovered, summary.ConditionOutcomesTotal))
+	}
+
+	fmt.Fprintf(&builder, "\nTotal %.1f%%\n\n", summary.Percentage)
+
+	table := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(table, "RELATION\tASSERTIONS\tCOVERED")
+
+	for _, relation := range coverage.Relations {
+		fmt.Fprintf(table, "%s#%s\t%d\t%s\n",
+			relation.Type, relation.Relation, relation.Assertions, yesNo(relation.Assertions > 0))
+	}
+
+	if len(coverage.Conditions) > 0 {
+		fmt.Fprintln(table, "\t\t")
+		fmt.Fprintln(table, "CONDITION\tTRUE\tFALSE")
+
+		for _, condition := range coverage.Conditions {
+			fmt.Fprintf(table, "%s\t%d\t%d\n", condition.Name, condition.TrueCount, condition.FalseCount)
+		}
+	}
+
+	_ = table.Flush()
+
+	return strings.TrimRight(builder.String(), "\n")
+}
+
+// WriteJSON writes the coverage and its summary as JSON.
+func (coverage *Coverage) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	err := encoder.Encode(struct {
+		Summary CoverageSummary `json:"summary"`
+		*Coverage
+	}{coverage.Summary(), coverage})
+	if err != nil {
+		return fmt.Errorf("failed to write coverage due to %w", err)
+	}
+
+	return nil
+}
+
+// WriteLCOV writes the coverage in an LCOV-like format: each relation is reported as a
+// function (FN/FNDA, with the number of assertions as its hit count), and each condition
+// as a branch with a true and a false outcome (BRDA). sourceName is used for the SF record.
+func (coverage *Coverage) WriteLCOV(w io.Writer, sourceName string) error {
+	summary := coverage.Summary()
+
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "TN:\nSF:%s\n", sourceName)
+
+	for _, relation := range coverage.Relations {
+		fmt.Fprintf(&builder, "FN:0,%s#%s\n", relation.Type, relation.Relation)
+	}
+
+	for _, relation := range coverage.Relations {
+		fmt.Fprintf(&builder, "FNDA:%d,%s#%s\n", relation.Assertions, relation.Type, relation.Relation)
+	}
+
+	fmt.Fprintf(&builder, "FNF:%d\nFNH:%d\n", summary.RelationsTotal, summary.RelationsCovered)
+
+	for index, condition := range coverage.Conditions {
+		fmt.Fprintf(&builder, "BRDA:0,%d,%s:true,%d\n", index, condition.Name, condition.TrueCount)
+		fmt.Fprintf(&builder, "BRDA:0,%d,%s:false,%d\n", index, condition.Name, condition.FalseCount)
+	}
+
+	fmt.Fprintf(&builder, "BRF:%d\nBRH:%d\nend_of_record\n", summary.ConditionOutcomesTotal,
+		summary.ConditionOutcomesCovered)
+
+	if _, err := io.WriteString(w, builder.String()); err != nil {
+		return fmt.Errorf("failed to write coverage due to %w", err)
+	}
+
+	return nil
+}
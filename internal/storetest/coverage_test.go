@@ -0,0 +1,115 @@
+package storetest
+
+import (
+	"bytes"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+func TestRunTestsCoverage(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{
+		Model: `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define owner: [user]
+    define viewer: [user with in_range] or owner
+
+condition in_range(x: int) {
+  x < 100
+}`,
+		Tuples: []client.ClientContextualTupleKey{{
+			User:      "user:anne",
+			Relation:  "viewer",
+			Object:    "document:1",
+			Condition: &openfga.RelationshipCondition{Name: "in_range"},
+		}},
+		Tests: []ModelTest{{
+			Name: "viewers",
+			Check: []ModelTestCheck{
+				{
+					User:       "user:anne",
+					Object:     "document:1",
+					Context:    &map[string]any{"x": "10"},
+					Assertions: map[string]bool{"viewer": true},
+				},
+				{
+					User:       "user:anne",
+					Object:     "document:1",
+					Context:    &map[string]any{"x": "10"},
+					Assertions: map[string]bool{"viewer": true},
+				},
+			},
+			ListObjects: []ModelTestListObjects{{
+				User:       "user:anne",
+				Type:       "document",
+				Context:    &map[string]any{"x": "200"},
+				Assertions: map[string][]string{"viewer": {}},
+			}},
+		}},
+	}
+
+	results, err := RunTests(t.Context(), nil, storeData, authorizationmodel.ModelFormatFGA,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1, Coverage: true})
+	require.NoError(t, err)
+	require.True(t, results.IsPassing())
+	require.NotNil(t, results.Coverage)
+
+	assert.Equal(t, []RelationCoverage{
+		{Type: "document", Relation: "owner"},
+		{Type: "document", Relation: "viewer", Assertions: 3},
+	}, results.Coverage.Relations)
+	// each of the two checks reads the conditional tuple with x=10, the list objects call with x=200
+	assert.Equal(t, []ConditionCoverage{{Name: "in_range", TrueCount: 2, FalseCount: 1}}, results.Coverage.Conditions)
+
+	summary := results.Coverage.Summary()
+	assert.Equal(t, 1, summary.RelationsCovered)
+	assert.Equal(t, 2, summary.RelationsTotal)
+	assert.Equal(t, 2, summary.ConditionOutcomesCovered)
+	assert.InDelta(t, 75.0, summary.Percentage, 0.01)
+}
+
+func TestCoverageMergeAndLCOV(t *testing.T) {
+	t.Parallel()
+
+	coverage := &Coverage{}
+	coverage.Merge(&Coverage{
+		Relations:  []RelationCoverage{{Type: "document", Relation: "viewer", Assertions: 2}},
+		Conditions: []ConditionCoverage{{Name: "in_range", TrueCount: 1}},
+	})
+	coverage.Merge(&Coverage{
+		Relations: []RelationCoverage{
+			{Type: "document", Relation: "viewer", Assertions: 1},
+			{Type: "document", Relation: "owner"},
+		},
+	})
+
+	var buffer bytes.Buffer
+	require.NoError(t, coverage.WriteLCOV(&buffer, "model.fga.yaml"))
+
+	assert.Equal(t, `TN:
+SF:model.fga.yaml
+FN:0,document#owner
+FN:0,document#viewer
+FNDA:0,document#owner
+FNDA:3,document#viewer
+FNF:2
+FNH:1
+BRDA:0,0,in_range:true,1
+BRDA:0,0,in_range:false,0
+BRF:2
+BRH:1
+end_of_record
+`, buffer.String())
+}
@@ -0,0 +1,79 @@
+package storetest
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// missingParametersPattern matches the parameters listed in the error of a check of a conditional
+// tuple whose condition is missing parameters, e.g. "is missing context parameters '[x y]'".
+var missingParametersPattern = regexp.MustCompile(`missing context parameters '\[([^\]]*)\]'`)
+
+func (expectation ModelTestCheckExpectation) expectsError() bool {
+	return expectation.Error != "" || len(expectation.MissingParameters) > 0
+}
+
+// allowed returns the expected result of the check, false when it is not asserted.
+func (expectation ModelTestCheckExpectation) allowed() bool {
+	return expectation.Allowed != nil && *expectation.Allowed
+}
+
+func (expectation ModelTestCheckExpectation) isMetBy(result ModelTestCheckSingleResult) bool {
+	if expectation.expectsError() {
+		return result.Error != nil &&
+			strings.Contains(result.Error.Error(), expectation.Error) &&
+			expectation.missingParametersMatch(result.Error.Error())
+	}
+
+	if result.Error != nil || result.Got == nil {
+		return false
+	}
+
+	return expectation.Allowed == nil || *expectation.Allowed == *result.Got
+}
+
+// missingParametersMatch returns whether the error reports exactly the expected missing
+// parameters, in any order.
+func (expectation ModelTestCheckExpectation) missingParametersMatch(message string) bool {
+	if len(expectation.MissingParameters) == 0 {
+		return true
+	}
+
+	match := missingParametersPattern.FindStringSubmatch(message)
+	if match == nil {
+		return false
+	}
+
+	missing := strings.Fields(match[1])
+	expected := slices.Clone(expectation.MissingParameters)
+
+	slices.Sort(missing)
+	slices.Sort(expected)
+
+	return slices.Equal(missing, expected)
+}
+
+func (expectation ModelTestCheckExpectation) String() string {
+	parts := []string{}
+
+	if expectation.Allowed != nil {
+		parts = append(parts, "allowed="+strconv.FormatBool(*expectation.Allowed))
+	}
+
+	if expectation.Error != "" {
+		parts = append(parts, fmt.Sprintf("error containing %q", expectation.Error))
+	}
+
+	if len(expectation.MissingParameters) > 0 {
+		parts = append(parts, fmt.Sprintf("missing parameters %v", expectation.MissingParameters))
+	}
+
+	if len(parts) == 0 {
+		return "no error"
+	}
+
+	return strings.Join(parts, " and ")
+}
@@ -0,0 +1,153 @@
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+var errMissingParameters = errors.New(
+	"failed to evaluate relationship condition: 'in_range' - tuple 'document:1#viewer@user:anne' " +
+		"is missing context parameters '[y x]'")
+
+func TestCheckExpectationIsMetBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		expectation ModelTestCheckExpectation
+		result      ModelTestCheckSingleResult
+		passing     bool
+	}{
+		{
+			name:        "allowed",
+			expectation: ModelTestCheckExpectation{Allowed: openfga.PtrBool(true)},
+			result:      ModelTestCheckSingleResult{Got: openfga.PtrBool(true)},
+			passing:     true,
+		},
+		{
+			name:        "not allowed",
+			expectation: ModelTestCheckExpectation{Allowed: openfga.PtrBool(true)},
+			result:      ModelTestCheckSingleResult{Got: openfga.PtrBool(false)},
+		},
+		{
+			name:        "unexpected error",
+			expectation: ModelTestCheckExpectation{Allowed: openfga.PtrBool(false)},
+			result:      ModelTestCheckSingleResult{Error: errMissingParameters},
+		},
+		{
+			name:        "error",
+			expectation: ModelTestCheckExpectation{Error: "is missing context parameters"},
+			result:      ModelTestCheckSingleResult{Error: errMissingParameters},
+			passing:     true,
+		},
+		{
+			name:        "no error",
+			expectation: ModelTestCheckExpectation{Error: "is missing context parameters"},
+			result:      ModelTestCheckSingleResult{Got: openfga.PtrBool(false)},
+		},
+		{
+			name:        "missing parameters",
+			expectation: ModelTestCheckExpectation{MissingParameters: []string{"x", "y"}},
+			result:      ModelTestCheckSingleResult{Error: errMissingParameters},
+			passing:     true,
+		},
+		{
+			name:        "other missing parameters",
+			expectation: ModelTestCheckExpectation{MissingParameters: []string{"x"}},
+			result:      ModelTestCheckSingleResult{Error: errMissingParameters},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			test.result.Expectation = &test.expectation
+			assert.Equal(t, test.passing, test.result.IsPassing())
+		})
+	}
+}
+
+func TestCheckExpectationString(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "no error", ModelTestCheckExpectation{}.String())
+	assert.Equal(t, "allowed=false", ModelTestCheckExpectation{Allowed: openfga.PtrBool(false)}.String())
+	assert.Equal(t, `error containing "not found" and missing parameters [x]`,
+		ModelTestCheckExpectation{Error: "not found", MissingParameters: []string{"x"}}.String())
+}
+
+func TestRunTestsWithExpectations(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{
+		Model: `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user with in_range]
+
+condition in_range(x: int) {
+  x < 100
+}`,
+		Tuples: []client.ClientContextualTupleKey{{
+			User:      "user:anne",
+			Relation:  "viewer",
+			Object:    "document:1",
+			Condition: &openfga.RelationshipCondition{Name: "in_range"},
+		}},
+		Tests: []ModelTest{{
+			Name: "negative paths",
+			Check: []ModelTestCheck{
+				{
+					User:   "user:anne",
+					Object: "document:1",
+					Expectations: map[string]ModelTestCheckExpectation{
+						"viewer": {MissingParameters: []string{"x"}},
+						"editor": {Error: "relation 'document#editor' not found"},
+					},
+				},
+				{
+					User:         "user:anne",
+					Object:       "document:1",
+					Context:      &map[string]any{"x": 5},
+					Expectations: map[string]ModelTestCheckExpectation{"viewer": {Allowed: openfga.PtrBool(true)}},
+				},
+			},
+		}},
+	}
+
+	results, err := RunTests(t.Context(), nil, storeData, authorizationmodel.ModelFormatFGA,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+	assert.Len(t, results.Results[0].CheckResults, 3)
+	assert.True(t, results.IsPassing(), results.FriendlyDisplay())
+}
+
+func TestValidateExpectedErrorConflict(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{Tests: []ModelTest{{
+		Name: "conflict",
+		Check: []ModelTestCheck{{
+			User:   "user:anne",
+			Object: "document:1",
+			Expectations: map[string]ModelTestCheckExpectation{
+				"viewer": {Allowed: openfga.PtrBool(false), Error: "not found"},
+			},
+		}},
+	}}}
+
+	require.ErrorContains(t, storeData.Validate(), ErrExpectedErrorConflict.Error())
+}
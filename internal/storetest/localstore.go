@@ -8,7 +8,6 @@ import (
 	pb "github.com/openfga/api/proto/openfga/v1"
 	"github.com/openfga/go-sdk/client"
 	"github.com/openfga/openfga/pkg/server"
-	"github.com/openfga/openfga/pkg/storage/memory"
 
 	"github.com/openfga/cli/internal/authorizationmodel"
 )
@@ -23,13 +22,18 @@ func initLocalStore(
 ) (*string, *string, error) {
 	var modelID *string
 
-	storeID := ulid.Make().String()
-
 	tuples, err := convertClientTupleKeysToProtoTupleKeys(testTuples)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	store, err := fgaServer.CreateStore(ctx, &pb.CreateStoreRequest{Name: ulid.Make().String()})
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck
+	}
+
+	storeID := store.GetId()
+
 	var authModelWriteReq *pb.WriteAuthorizationModelRequest
 
 	if model != nil {
@@ -44,6 +48,8 @@ func initLocalStore(
 	if authModelWriteReq != nil {
 		writtenModel, err := fgaServer.WriteAuthorizationModel(ctx, authModelWriteReq)
 		if err != nil {
+			_ = deleteLocalStore(ctx, fgaServer, storeID)
+
 			return nil, nil, err //nolint:wrapcheck
 		}
 
@@ -63,6 +69,8 @@ func initLocalStore(
 
 			_, err := fgaServer.Write(ctx, writeRequest)
 			if err != nil {
+				_ = deleteLocalStore(ctx, fgaServer, storeID)
+
 				return nil, nil, err //nolint:wrapcheck
 			}
 		}
@@ -71,46 +79,119 @@ func initLocalStore(
 	return &storeID, modelID, nil
 }
 
+// deleteLocalStore deletes a store created by initLocalStore, freeing its tuples and model. It
+// runs even once ctx is canceled, so that stopping a watch run does not leave stores behind.
+func deleteLocalStore(ctx context.Context, fgaServer *server.Server, storeID string) error {
+	_, err := fgaServer.DeleteStore(context.WithoutCancel(ctx), &pb.DeleteStoreRequest{StoreId: storeID})
+
+	return err //nolint:wrapcheck
+}
+
+// NewEmbeddedServer starts an OpenFGA server in the process, backed by in-memory datastores.
+// Calling the returned function stops it and frees its data.
+func NewEmbeddedServer(serverConfig LocalServerConfig) (*server.Server, func(), error) {
+	datastore := newStoreDatastores(serverConfig.MaxTypesPerAuthorizationModel)
+
+	fgaServer, err := server.NewServerWithOpts(
+		server.WithDatastore(conditionRecordingDatastore{OpenFGADatastore: datastore}),
+	)
+	if err != nil {
+		datastore.Close()
+
+		return nil, func() {}, err //nolint:wrapcheck
+	}
+
+	return fgaServer, func() {
+		datastore.Close()
+		fgaServer.Close()
+	}, nil
+}
+
+// readLocalModel reads the model of the store file, or returns nil if the tests run against a remote store.
+func readLocalModel(
+	storeData *StoreData,
+	format authorizationmodel.ModelFormat,
+) (*authorizationmodel.AuthzModel, error) {
+	if storeData.Model == "" {
+		return nil, nil //nolint:nilnil
+	}
+
+	authModel := &authorizationmodel.AuthzModel{}
+
+	err := authModel.ReadModelFromStringContained(storeData.Model, format, storeData.ModelContainBase())
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return authModel, nil
+}
+
 func getLocalServerModelAndTuples(
 	storeData *StoreData,
 	format authorizationmodel.ModelFormat,
 	serverConfig LocalServerConfig,
 ) (*server.Server, *authorizationmodel.AuthzModel, func(), error) {
-	var fgaServer *server.Server
-
-	var authModel *authorizationmodel.AuthzModel
-
 	stopServerFn := func() {}
 
 	if storeData.Model == "" {
-		return fgaServer, authModel, stopServerFn, nil
+		return nil, nil, stopServerFn, nil
 	}
 
 	// If we have at least one local test, initialize the local server
-	datastore := memory.New(
-		memory.WithMaxTypesPerAuthorizationModel(serverConfig.MaxTypesPerAuthorizationModel),
-	)
+	fgaServer, stopServerFn, err := NewEmbeddedServer(serverConfig)
+	if err != nil {
+		return nil, nil, stopServerFn, err
+	}
 
-	fgaServer, err := server.NewServerWithOpts(
-		server.WithDatastore(datastore),
-	)
+	authModel, err := readLocalModel(storeData, format)
 	if err != nil {
-		return nil, nil, stopServerFn, err //nolint:wrapcheck
+		stopServerFn()
+
+		return nil, nil, func() {}, err
 	}
 
-	tempModel := authorizationmodel.AuthzModel{}
+	return fgaServer, authModel, stopServerFn, nil
+}
+
+// LocalServer is an embedded OpenFGA server that is kept running to run the tests of several
+// store files, such as the test files of a watch session. Each test runs in a store of its own,
+// which is deleted once the test ran.
+type LocalServer struct {
+	fgaServer *server.Server
+	stop      func()
+}
 
-	err = tempModel.ReadModelFromStringContained(storeData.Model, format, storeData.ModelContainBase())
+// NewLocalServer starts an embedded OpenFGA server. Close stops it.
+func NewLocalServer(serverConfig LocalServerConfig) (*LocalServer, error) {
+	fgaServer, stop, err := NewEmbeddedServer(serverConfig)
 	if err != nil {
-		return nil, nil, stopServerFn, err //nolint:wrapcheck
+		return nil, err
 	}
 
-	authModel = &tempModel
+	return &LocalServer{fgaServer: fgaServer, stop: stop}, nil
+}
 
-	stopServerFn = func() {
-		datastore.Close()
-		fgaServer.Close()
+func (localServer *LocalServer) Close() {
+	localServer.stop()
+}
+
+// RunTests runs the tests of the store file, against the local server if the store file has
+// a model, and against the store fgaClient is configured for otherwise.
+func (localServer *LocalServer) RunTests(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	storeData *StoreData,
+	format authorizationmodel.ModelFormat,
+	options RunOptions,
+) (TestResults, error) {
+	if err := storeData.Validate(); err != nil {
+		return TestResults{}, err
 	}
 
-	return fgaServer, authModel, stopServerFn, nil
+	authModel, err := readLocalModel(storeData, format)
+	if err != nil {
+		return TestResults{}, err
+	}
+
+	return runTests(ctx, fgaClient, localServer.fgaServer, authModel, storeData, options)
 }
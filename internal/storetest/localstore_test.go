@@ -6,6 +6,9 @@ import (
 	"strings"
 	"testing"
 
+	pb "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/go-sdk/client"
+	"github.com/openfga/openfga/pkg/server"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -90,3 +93,35 @@ func TestGetLocalServerModelAndTuples_MaxTypesLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestRunLocalTestDeletesItsStore(t *testing.T) {
+	t.Parallel()
+
+	datastore := newStoreDatastores(100)
+
+	fgaServer, err := server.NewServerWithOpts(server.WithDatastore(datastore))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		fgaServer.Close()
+		datastore.Close()
+	})
+
+	authModel, err := readLocalModel(&StoreData{Model: buildModelWithNTypes(2)}, authorizationmodel.ModelFormatDefault)
+	require.NoError(t, err)
+
+	result, err := RunLocalTest(t.Context(), fgaServer, ModelTest{
+		Check: []ModelTestCheck{{
+			User:       "user:anne",
+			Object:     "resource1:1",
+			Assertions: map[string]bool{"owner": true},
+		}},
+	}, []client.ClientContextualTupleKey{{User: "user:anne", Relation: "owner", Object: "resource1:1"}}, authModel)
+	require.NoError(t, err)
+	assert.True(t, result.IsPassing())
+
+	stores, err := fgaServer.ListStores(t.Context(), &pb.ListStoresRequest{})
+	require.NoError(t, err)
+	assert.Empty(t, stores.GetStores())
+	assert.Empty(t, datastore.stores)
+}
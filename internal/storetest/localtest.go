@@ -26,57 +26,40 @@ func RunLocalCheckTest(
 	tuples []client.ClientContextualTupleKey,
 	options ModelTestOptions,
 ) []ModelTestCheckSingleResult {
+	if len(checkTest.Cases) > 0 {
+		results := []ModelTestCheckSingleResult{}
+
+		for _, check := range expandCheckCases(checkTest) {
+			results = append(results, RunLocalCheckTest(ctx, fgaServer, check, tuples, options)...)
+		}
+
+		return results
+	}
+
 	users := GetEffectiveUsers(checkTest)
 	objects := GetEffectiveObjects(checkTest)
-	results := make([]ModelTestCheckSingleResult, 0, len(users)*len(objects)*len(checkTest.Assertions))
+	results := make([]ModelTestCheckSingleResult, 0,
+		len(users)*len(objects)*(len(checkTest.Assertions)+len(checkTest.Expectations)))
 
 	for _, user := range users {
 		for _, object := range objects {
-			for relation, expectation := range checkTest.Assertions {
-				result := ModelTestCheckSingleResult{
-					Request: client.ClientCheckRequest{
-						User:             user,
-						Relation:         relation,
-						Object:           object,
-						ContextualTuples: tuples,
-						Context:          checkTest.Context,
-					},
-					Expected: expectation,
-				}
-
-				var (
-					reqCtx *structpb.Struct
-					err    error
-				)
-
-				if checkTest.Context != nil {
-					reqCtx, err = structpb.NewStruct(*checkTest.Context)
-				}
+			request := client.ClientCheckRequest{
+				User:             user,
+				Object:           object,
+				ContextualTuples: tuples,
+				Context:          checkTest.Context,
+			}
 
-				if err != nil {
-					result.Error = err
-				} else {
-					response, err := RunSingleLocalCheckTest(ctx, fgaServer,
-						&pb.CheckRequest{
-							StoreId:              *options.StoreID,
-							AuthorizationModelId: *options.ModelID,
-							TupleKey: &pb.CheckRequestTupleKey{
-								User:     user,
-								Relation: relation,
-								Object:   object,
-							},
-							Context: reqCtx,
-						},
-					)
-					if err != nil {
-						result.Error = err
-					} else if response != nil {
-						result.Got = &response.Allowed
-						result.TestResult = result.IsPassing()
-					}
-				}
+			for relation, expectation := range checkTest.Assertions {
+				request.Relation = relation
+				results = append(results, runLocalCheck(ctx, fgaServer, options,
+					ModelTestCheckSingleResult{Request: request, Expected: expectation}))
+			}
 
-				results = append(results, result)
+			for relation, expectation := range checkTest.Expectations {
+				request.Relation = relation
+				results = append(results, runLocalCheck(ctx, fgaServer, options,
+					ModelTestCheckSingleResult{Request: request, Expected: expectation.allowed(), Expectation: &expectation}))
 			}
 		}
 	}
@@ -84,6 +67,53 @@ func RunLocalCheckTest(
 	return results
 }
 
+// runLocalCheck runs the check of the result's request, and fills in the rest of the result.
+func runLocalCheck(
+	ctx context.Context,
+	fgaServer *server.Server,
+	options ModelTestOptions,
+	result ModelTestCheckSingleResult,
+) ModelTestCheckSingleResult {
+	var (
+		reqCtx *structpb.Struct
+		err    error
+	)
+
+	if result.Request.Context != nil {
+		reqCtx, err = structpb.NewStruct(*result.Request.Context)
+	}
+
+	if err != nil {
+		result.Error = err
+
+		return result
+	}
+
+	response, err := RunSingleLocalCheckTest(withConditionQuery(ctx, reqCtx), fgaServer,
+		&pb.CheckRequest{
+			StoreId:              *options.StoreID,
+			AuthorizationModelId: *options.ModelID,
+			TupleKey: &pb.CheckRequestTupleKey{
+				User:     result.Request.User,
+				Relation: result.Request.Relation,
+				Object:   result.Request.Object,
+			},
+			Context: reqCtx,
+		},
+	)
+	if err != nil {
+		result.Error = err
+	} else if response != nil {
+		result.Got = &response.Allowed
+	}
+
+	if result.Got != nil || result.Expectation != nil {
+		result.TestResult = result.IsPassing()
+	}
+
+	return result
+}
+
 func RunSingleLocalListObjectsTest(
 	ctx context.Context,
 	fgaServer *server.Server,
@@ -129,7 +159,7 @@ func RunLocalListObjectsTest(
 			continue
 		}
 
-		response, err := RunSingleLocalListObjectsTest(ctx, fgaServer,
+		response, err := RunSingleLocalListObjectsTest(withConditionQuery(ctx, reqCtx), fgaServer,
 			&pb.ListObjectsRequest{
 				StoreId:              *options.StoreID,
 				AuthorizationModelId: *options.ModelID,
@@ -204,7 +234,7 @@ func RunLocalListUsersTest(
 		if err != nil {
 			result.Error = err
 		} else {
-			response, err := RunSingleLocalListUsersTest(ctx, fgaServer,
+			response, err := RunSingleLocalListUsersTest(withConditionQuery(ctx, reqCtx), fgaServer,
 				&pb.ListUsersRequest{
 					StoreId:              *options.StoreID,
 					AuthorizationModelId: *options.ModelID,
@@ -266,6 +296,10 @@ func RunLocalTest(
 		listUsersResults = append(listUsersResults, results...)
 	}
 
+	if err := deleteLocalStore(ctx, fgaServer, *storeID); err != nil {
+		return TestResult{}, err
+	}
+
 	return TestResult{
 		Name:               test.Name,
 		Description:        test.Description,
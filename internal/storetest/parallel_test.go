@@ -0,0 +1,47 @@
+package storetest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+func TestRunTestsInParallelKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	storeData := &StoreData{
+		Model: `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]`,
+	}
+
+	for index := range 8 {
+		storeData.Tests = append(storeData.Tests, ModelTest{
+			Name: fmt.Sprintf("test-%d", index),
+			Check: []ModelTestCheck{{
+				User:       "user:anne",
+				Object:     "document:1",
+				Assertions: map[string]bool{"viewer": false},
+			}},
+		})
+	}
+
+	results, err := RunTests(t.Context(), nil, storeData, authorizationmodel.ModelFormatFGA,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 4})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 8)
+
+	for index, result := range results.Results {
+		assert.Equal(t, fmt.Sprintf("test-%d", index), result.Name)
+		assert.True(t, result.IsPassing())
+	}
+}
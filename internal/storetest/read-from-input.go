@@ -18,8 +18,10 @@ package storetest
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/openfga/cli/internal/authorizationmodel"
 
@@ -29,7 +31,7 @@ import (
 // ReadFromFile is used to read and parse the Store file.
 //
 // Files referenced from within the store YAML (model_file, tuple_file,
-// tuple_files, and per-test tuple_file) are, by default, contained to the
+// tuple_files, per-test tuple_file, and extends) are, by default, contained to the
 // directory holding the store file and must be regular files. Set
 // allowExternalFiles to true to permit references that resolve outside that
 // directory (e.g. via "..") for trusted workflows.
@@ -40,8 +42,6 @@ func ReadFromFile(
 ) (authorizationmodel.ModelFormat, *StoreData, error) {
 	format := authorizationmodel.ModelFormatDefault
 
-	var storeData StoreData
-
 	absFileName := fileName
 
 	// Only join with basePath if fileName is not absolute and basePath is provided
@@ -58,16 +58,32 @@ func ReadFromFile(
 	}
 	defer testFile.Close()
 
-	decoder := yaml.NewDecoder(testFile)
+	return readStoreFile(fileName, absFileName, testFile, allowExternalFiles, nil)
+}
+
+// readStoreFile parses the store file read from r and loads the files it references, including the
+// store file it extends. extending holds the absolute paths of the store files that extend it.
+func readStoreFile(
+	fileName string,
+	resolvedFileName string,
+	r io.Reader,
+	allowExternalFiles bool,
+	extending []string,
+) (authorizationmodel.ModelFormat, *StoreData, error) {
+	format := authorizationmodel.ModelFormatDefault
+
+	var storeData StoreData
+
+	decoder := yaml.NewDecoder(r)
 	decoder.KnownFields(true)
 
-	err = decoder.Decode(&storeData)
+	err := decoder.Decode(&storeData)
 	if err != nil {
 		return format, nil, fmt.Errorf("failed to unmarshal file %s due to %w", fileName, err)
 	}
 
 	// Use the directory of the resolved file path for nested file references
-	resolvedBasePath := filepath.Dir(absFileName)
+	resolvedBasePath := filepath.Dir(resolvedFileName)
 
 	format, err = storeData.LoadModel(resolvedBasePath, allowExternalFiles)
 	if err != nil {
@@ -79,6 +95,24 @@ func ReadFromFile(
 		return format, nil, err
 	}
 
+	if storeData.Extends != "" {
+		absFileName, err := filepath.Abs(resolvedFileName)
+		if err != nil {
+			return format, nil, fmt.Errorf("failed to resolve file %s due to %w", fileName, err)
+		}
+
+		format, err = storeData.loadExtends(
+			resolvedBasePath, allowExternalFiles, format, append(slices.Clone(extending), absFileName),
+		)
+		if err != nil {
+			return format, nil, err
+		}
+	}
+
+	if err = storeData.loadFixtures(); err != nil {
+		return format, nil, err
+	}
+
 	if err = storeData.Validate(); err != nil {
 		return format, nil, err
 	}
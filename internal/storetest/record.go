@@ -0,0 +1,315 @@
+package storetest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"gopkg.in/yaml.v3"
+)
+
+const recordIndent = 2
+
+var (
+	// ErrInvalidRecordFile is returned when a test file to record to is not a store file.
+	ErrInvalidRecordFile = errors.New("test file to record to is not a store file")
+	// ErrRecordTuplesMismatch is returned when the test to record to runs its assertions with other
+	// tuples than the contextual tuples of the request.
+	ErrRecordTuplesMismatch = errors.New("test has other tuples than the contextual tuples of the request")
+)
+
+// RecordCheck appends the check, with allowed as its assertion, to the test named testName in the
+// test file, creating the file and the test if they do not exist yet. It returns the name of the
+// test, see recordTestName.
+func RecordCheck(
+	fileName string,
+	testName string,
+	request client.ClientCheckRequest,
+	allowed bool,
+) (string, error) {
+	return recordAssertion(fileName, testName, request.ContextualTuples, "check", ModelTestCheck{
+		User:       request.User,
+		Object:     request.Object,
+		Context:    recordedContext(request.Context),
+		Assertions: map[string]bool{request.Relation: allowed},
+	})
+}
+
+// RecordListObjects appends the list objects request, with objects as its assertion, to the test
+// named testName in the test file, creating the file and the test if they do not exist yet. It
+// returns the name of the test, see recordTestName.
+func RecordListObjects(
+	fileName string,
+	testName string,
+	request client.ClientListObjectsRequest,
+	objects []string,
+) (string, error) {
+	return recordAssertion(fileName, testName, request.ContextualTuples, "list_objects", ModelTestListObjects{
+		User:       request.User,
+		Type:       request.Type,
+		Context:    recordedContext(request.Context),
+		Assertions: map[string][]string{request.Relation: objects},
+	})
+}
+
+// RecordListUsers appends the list users request, with users as its assertion, to the test named
+// testName in the test file, creating the file and the test if they do not exist yet. It returns
+// the name of the test, see recordTestName.
+func RecordListUsers(
+	fileName string,
+	testName string,
+	request client.ClientListUsersRequest,
+	users []openfga.User,
+) (string, error) {
+	return recordAssertion(fileName, testName, request.ContextualTuples, "list_users", ModelTestListUsers{
+		Object:     request.Object.Type + ":" + request.Object.Id,
+		UserFilter: request.UserFilters,
+		Context:    recordedContext(request.Context),
+		Assertions: map[string]ModelTestListUsersAssertion{
+			request.Relation: {Users: convertOpenfgaUsers(users)},
+		},
+	})
+}
+
+// recordedContext leaves out an empty context, which the queries send when none was given.
+func recordedContext(context *map[string]any) *map[string]any {
+	if context == nil || len(*context) == 0 {
+		return nil
+	}
+
+	return context
+}
+
+// recordTestName returns the name of the test that a request with the contextual tuples is
+// recorded to: testName, followed by the tuples when there are any. The tuples of a test apply to
+// all of its assertions, so requests with different contextual tuples are recorded to different
+// tests.
+func recordTestName(testName string, tuples []client.ClientContextualTupleKey) string {
+	if len(tuples) == 0 {
+		return testName
+	}
+
+	keys := make([]string, 0, len(tuples))
+	for _, tuple := range tuples {
+		keys = append(keys, tuple.User+" "+tuple.Relation+" "+tuple.Object)
+	}
+
+	slices.Sort(keys)
+
+	return testName + " with " + strings.Join(slices.Compact(keys), ", ")
+}
+
+// recordAssertion edits the test file as a YAML document rather than as StoreData, so that the
+// comments and the fields that are not part of the tests are kept as they are. The contextual
+// tuples become the tuples of the test, see recordTestName.
+func recordAssertion(
+	fileName string,
+	testName string,
+	tuples []client.ClientContextualTupleKey,
+	key string,
+	assertion any,
+) (string, error) {
+	testName = recordTestName(testName, tuples)
+
+	document, err := readRecordFile(fileName)
+	if err != nil {
+		return testName, err
+	}
+
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return testName, fmt.Errorf("%w: %q", ErrInvalidRecordFile, fileName)
+	}
+
+	root := document.Content[0]
+
+	tests, err := mappingSequence(root, "tests")
+	if err != nil {
+		return testName, fmt.Errorf("%w: %q: %w", ErrInvalidRecordFile, fileName, err)
+	}
+
+	test, err := findOrAddTest(tests, testName)
+	if err != nil {
+		return testName, err
+	}
+
+	if err := setTestTuples(test, tuples); err != nil {
+		return testName, fmt.Errorf("failed to record to test %q of %q: %w", testName, fileName, err)
+	}
+
+	assertions, err := mappingSequence(test, key)
+	if err != nil {
+		return testName, fmt.Errorf("%w: %q: %w", ErrInvalidRecordFile, fileName, err)
+	}
+
+	if err := appendEncoded(assertions, assertion); err != nil {
+		return testName, err
+	}
+
+	var buffer bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(recordIndent)
+
+	if err := encoder.Encode(document); err != nil {
+		return testName, fmt.Errorf("failed to marshal test file %q: %w", fileName, err)
+	}
+
+	if err := os.WriteFile(fileName, buffer.Bytes(), 0o600); err != nil { //nolint:mnd
+		return testName, fmt.Errorf("failed to write test file %q: %w", fileName, err)
+	}
+
+	return testName, nil
+}
+
+// readRecordFile reads the test file, or returns the document of a new store named after the file
+// when it does not exist.
+func readRecordFile(fileName string) (*yaml.Node, error) {
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(bytes.TrimSpace(data)) == 0) {
+		baseName := filepath.Base(fileName)
+		storeName := strings.TrimSuffix(strings.TrimSuffix(baseName, filepath.Ext(baseName)), ".fga")
+
+		root := &yaml.Node{}
+		if err := root.Encode(map[string]any{"name": storeName}); err != nil {
+			return nil, fmt.Errorf("failed to create test file %q: %w", fileName, err)
+		}
+
+		return &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{root}}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read test file %q: %w", fileName, err)
+	}
+
+	document := &yaml.Node{}
+	if err := yaml.Unmarshal(data, document); err != nil {
+		return nil, fmt.Errorf("failed to parse test file %q: %w", fileName, err)
+	}
+
+	return document, nil
+}
+
+// mappingValue returns the value of key in the mapping, or nil if it is not set.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for index := 0; index+1 < len(mapping.Content); index += 2 {
+		if mapping.Content[index].Value == key {
+			return mapping.Content[index+1]
+		}
+	}
+
+	return nil
+}
+
+// mappingSequence returns the sequence at key in the mapping, adding an empty one if it is not
+// set, or is set to null.
+func mappingSequence(mapping *yaml.Node, key string) (*yaml.Node, error) {
+	value := mappingValue(mapping, key)
+
+	switch {
+	case value == nil:
+		value = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	case value.Kind == yaml.ScalarNode && value.Tag == "!!null":
+		*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	case value.Kind != yaml.SequenceNode:
+		return nil, fmt.Errorf("%q is not a list", key) //nolint:err113
+	}
+
+	value.Style = 0
+
+	return value, nil
+}
+
+func findOrAddTest(tests *yaml.Node, testName string) (*yaml.Node, error) {
+	for _, test := range tests.Content {
+		if name := mappingValue(test, "name"); test.Kind == yaml.MappingNode && name != nil && name.Value == testName {
+			return test, nil
+		}
+	}
+
+	if err := appendEncoded(tests, map[string]any{"name": testName}); err != nil {
+		return nil, err
+	}
+
+	return tests.Content[len(tests.Content)-1], nil
+}
+
+// setTestTuples adds the contextual tuples to a test that has no tuples and no assertions yet. A
+// test that already has tuples or assertions must have the same tuples as the request, as they
+// apply to all of its assertions.
+func setTestTuples(test *yaml.Node, tuples []client.ClientContextualTupleKey) error {
+	existing := []client.ClientContextualTupleKey{}
+
+	if testTuples := mappingValue(test, "tuples"); testTuples != nil {
+		if err := testTuples.Decode(&existing); err != nil {
+			return fmt.Errorf("failed to read the tuples of the test: %w", err)
+		}
+	}
+
+	if sameTupleKeys(existing, tuples) {
+		return nil
+	}
+
+	if len(existing) > 0 || hasRecordedAssertions(test) {
+		return ErrRecordTuplesMismatch
+	}
+
+	testTuples, err := mappingSequence(test, "tuples")
+	if err != nil {
+		return err
+	}
+
+	for index, tuple := range tuples {
+		if !containsTupleKey(tuples[:index], tuple) {
+			if err := appendEncoded(testTuples, tuple); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sameTupleKeys reports whether both lists have the same user, relation and object tuples.
+func sameTupleKeys(tuples []client.ClientContextualTupleKey, otherTuples []client.ClientContextualTupleKey) bool {
+	for _, tuple := range tuples {
+		if !containsTupleKey(otherTuples, tuple) {
+			return false
+		}
+	}
+
+	for _, tuple := range otherTuples {
+		if !containsTupleKey(tuples, tuple) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasRecordedAssertions(test *yaml.Node) bool {
+	for _, key := range []string{"check", "list_objects", "list_users"} {
+		if assertions := mappingValue(test, key); assertions != nil && len(assertions.Content) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func appendEncoded(sequence *yaml.Node, value any) error {
+	node := &yaml.Node{}
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("failed to marshal recorded assertion: %w", err)
+	}
+
+	sequence.Content = append(sequence.Content, node)
+
+	return nil
+}
@@ -0,0 +1,213 @@
+package storetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecordToNewFile(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "regressions.fga.yaml")
+
+	_, err := RecordCheck(fileName, "recorded", client.ClientCheckRequest{
+		User:     "user:anne",
+		Relation: "viewer",
+		Object:   "document:1",
+		Context:  &map[string]any{},
+	}, true)
+	require.NoError(t, err)
+
+	_, err = RecordListObjects(fileName, "recorded", client.ClientListObjectsRequest{
+		User:     "user:anne",
+		Relation: "viewer",
+		Type:     "document",
+		Context:  &map[string]any{"x": 1},
+	}, []string{"document:1"})
+	require.NoError(t, err)
+
+	testName, err := RecordListUsers(fileName, "recorded", client.ClientListUsersRequest{
+		Object:      openfga.FgaObject{Type: "document", Id: "1"},
+		Relation:    "viewer",
+		UserFilters: []openfga.UserTypeFilter{{Type: "user"}},
+	}, []openfga.User{{Object: &openfga.FgaObject{Type: "user", Id: "anne"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "recorded", testName)
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, `name: regressions
+tests:
+  - name: recorded
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: true
+    list_objects:
+      - user: user:anne
+        type: document
+        context:
+          x: 1
+        assertions:
+          viewer:
+            - document:1
+    list_users:
+      - object: document:1
+        user_filter:
+          - type: user
+        assertions:
+          viewer:
+            users:
+              - user:anne
+`, string(data))
+}
+
+func TestRecordToExistingFile(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(`# the store under test
+name: docs
+model_file: model.fga
+tests:
+  - name: owners
+    check: []
+`), 0o600))
+
+	contextualTuples := []client.ClientContextualTupleKey{
+		{User: "user:anne", Relation: "member", Object: "group:eng"},
+	}
+
+	for range 2 {
+		testName, err := RecordCheck(fileName, "members", client.ClientCheckRequest{
+			User:             "user:anne",
+			Relation:         "viewer",
+			Object:           "document:1",
+			ContextualTuples: contextualTuples,
+		}, false)
+		require.NoError(t, err)
+		assert.Equal(t, "members with user:anne member group:eng", testName)
+	}
+
+	data, err := os.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, `# the store under test
+name: docs
+model_file: model.fga
+tests:
+  - name: owners
+    check: []
+  - name: members with user:anne member group:eng
+    tuples:
+      - user: user:anne
+        relation: member
+        object: group:eng
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: false
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: false
+`, string(data))
+}
+
+func TestRecordToInvalidFile(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte("name: docs\ntests: all of them\n"), 0o600))
+
+	_, err := RecordCheck(fileName, "recorded", client.ClientCheckRequest{
+		User: "user:anne", Relation: "viewer", Object: "document:1",
+	}, true)
+	require.ErrorIs(t, err, ErrInvalidRecordFile)
+}
+
+func TestRecordWithContextualTuplesRuns(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	writeTempFile(t, tempDir, "model.fga", `model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]`)
+	fileName := writeTempFile(t, tempDir, "store.fga.yaml", "name: docs\nmodel_file: model.fga\n")
+
+	// anne can view document:1 only through the contextual tuple of the first check
+	for _, request := range []client.ClientCheckRequest{{
+		User:     "user:anne",
+		Relation: "viewer",
+		Object:   "document:1",
+		ContextualTuples: []client.ClientContextualTupleKey{
+			{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		},
+	}, {
+		User:     "user:anne",
+		Relation: "viewer",
+		Object:   "document:1",
+		ContextualTuples: []client.ClientContextualTupleKey{
+			{User: "user:bob", Relation: "viewer", Object: "document:1"},
+		},
+	}} {
+		allowed := request.ContextualTuples[0].User == request.User
+
+		_, err := RecordCheck(fileName, "recorded", request, allowed)
+		require.NoError(t, err)
+	}
+
+	format, storeData, err := ReadFromFile(fileName, "", false)
+	require.NoError(t, err)
+	require.Len(t, storeData.Tests, 2)
+
+	results, err := RunTests(t.Context(), nil, storeData, format,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1})
+	require.NoError(t, err)
+	assert.True(t, results.IsPassing(), results.FriendlyDisplay())
+}
+
+func TestRecordToTestWithOtherTuples(t *testing.T) {
+	t.Parallel()
+
+	fileName := filepath.Join(t.TempDir(), "store.fga.yaml")
+	require.NoError(t, os.WriteFile(fileName, []byte(`name: docs
+tests:
+  - name: recorded
+    check:
+      - user: user:anne
+        object: document:1
+        assertions:
+          viewer: false
+  - name: owners
+    tuples:
+      - user: user:anne
+        relation: owner
+        object: document:1
+`), 0o600))
+
+	_, err := RecordCheck(fileName, "recorded", client.ClientCheckRequest{
+		User:             "user:anne",
+		Relation:         "viewer",
+		Object:           "document:1",
+		ContextualTuples: []client.ClientContextualTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:1"}},
+	}, true)
+	require.NoError(t, err, "the request is recorded to a test named after its contextual tuples")
+
+	_, err = RecordCheck(fileName, "owners", client.ClientCheckRequest{
+		User: "user:anne", Relation: "viewer", Object: "document:1",
+	}, true)
+	require.ErrorIs(t, err, ErrRecordTuplesMismatch)
+}
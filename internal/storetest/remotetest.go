@@ -12,15 +12,23 @@ func RunSingleRemoteCheckTest(
 	checkRequest client.ClientCheckRequest,
 	expectation bool,
 ) ModelTestCheckSingleResult {
-	res, err := fgaClient.Check(ctx).Body(checkRequest).Execute()
+	return runRemoteCheck(ctx, fgaClient, ModelTestCheckSingleResult{Request: checkRequest, Expected: expectation})
+}
 
-	result := ModelTestCheckSingleResult{
-		Request:  checkRequest,
-		Expected: expectation,
-		Error:    err,
-	}
+// runRemoteCheck runs the check of the result's request, and fills in the rest of the result.
+func runRemoteCheck(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	result ModelTestCheckSingleResult,
+) ModelTestCheckSingleResult {
+	res, err := fgaClient.Check(ctx).Body(result.Request).Execute()
+
+	result.Error = err
 	if err == nil && res != nil {
 		result.Got = res.Allowed
+	}
+
+	if result.Got != nil || result.Expectation != nil {
 		result.TestResult = result.IsPassing()
 	}
 
@@ -33,26 +41,39 @@ func RunRemoteCheckTest(
 	checkTest ModelTestCheck,
 	tuples []client.ClientContextualTupleKey,
 ) []ModelTestCheckSingleResult {
+	if len(checkTest.Cases) > 0 {
+		results := []ModelTestCheckSingleResult{}
+
+		for _, check := range expandCheckCases(checkTest) {
+			results = append(results, RunRemoteCheckTest(ctx, fgaClient, check, tuples)...)
+		}
+
+		return results
+	}
+
 	users := GetEffectiveUsers(checkTest)
 	objects := GetEffectiveObjects(checkTest)
-	results := make([]ModelTestCheckSingleResult, 0, len(users)*len(objects)*len(checkTest.Assertions))
+	results := make([]ModelTestCheckSingleResult, 0,
+		len(users)*len(objects)*(len(checkTest.Assertions)+len(checkTest.Expectations)))
 
 	for _, user := range users {
 		for _, object := range objects {
+			request := client.ClientCheckRequest{
+				User:             user,
+				Object:           object,
+				Context:          checkTest.Context,
+				ContextualTuples: tuples,
+			}
+
 			for relation, expectation := range checkTest.Assertions {
-				result := RunSingleRemoteCheckTest(
-					ctx,
-					fgaClient,
-					client.ClientCheckRequest{
-						User:             user,
-						Relation:         relation,
-						Object:           object,
-						Context:          checkTest.Context,
-						ContextualTuples: tuples,
-					},
-					expectation,
-				)
-				results = append(results, result)
+				request.Relation = relation
+				results = append(results, RunSingleRemoteCheckTest(ctx, fgaClient, request, expectation))
+			}
+
+			for relation, expectation := range checkTest.Expectations {
+				request.Relation = relation
+				results = append(results, runRemoteCheck(ctx, fgaClient,
+					ModelTestCheckSingleResult{Request: request, Expected: expectation.allowed(), Expectation: &expectation}))
 			}
 		}
 	}
@@ -0,0 +1,283 @@
+package storetest
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/openfga/cli/internal/clierrors"
+)
+
+type ReportFormat string
+
+const (
+	ReportFormatNone  ReportFormat = ""
+	ReportFormatJSON  ReportFormat = "json"
+	ReportFormatJUnit ReportFormat = "junit"
+	ReportFormatTAP   ReportFormat = "tap"
+)
+
+func (format *ReportFormat) String() string {
+	return string(*format)
+}
+
+func (format *ReportFormat) Set(v string) error {
+	switch v {
+	case "json", "junit", "tap":
+		*format = ReportFormat(v)
+
+		return nil
+	default:
+		return fmt.Errorf(`%w: must be one of "%v", "%v" or "%v"`,
+			clierrors.ErrInvalidFormat, ReportFormatJUnit, ReportFormatTAP, ReportFormatJSON)
+	}
+}
+
+func (format *ReportFormat) Type() string {
+	return "format"
+}
+
+// ReportFormatFromFileName returns the report format matching the extension of fileName
+// (.xml for JUnit, .tap and .json), or ReportFormatNone if there is none.
+func ReportFormatFromFileName(fileName string) ReportFormat {
+	switch {
+	case strings.HasSuffix(fileName, ".xml"):
+		return ReportFormatJUnit
+	case strings.HasSuffix(fileName, ".tap"):
+		return ReportFormatTAP
+	case strings.HasSuffix(fileName, ".json"):
+		return ReportFormatJSON
+	default:
+		return ReportFormatNone
+	}
+}
+
+// reportCase is a single check, list_objects or list_users assertion of a test.
+type reportCase struct {
+	name     string
+	passing  bool
+	expected string
+	got      string
+	err      error
+}
+
+func (reportCase reportCase) failureMessage() string {
+	message := fmt.Sprintf("expected=%s, got=%s", reportCase.expected, reportCase.got)
+	if reportCase.err != nil {
+		message += fmt.Sprintf(", error=%v", reportCase.err)
+	}
+
+	return message
+}
+
+func requestContextSuffix(context *map[string]any) string {
+	if context == nil {
+		return ""
+	}
+
+	return fmt.Sprintf(", context:%v", context)
+}
+
+// reportCases lists the assertions of the test, named and formatted as in FriendlyDisplay.
+func (result TestResult) reportCases() []reportCase {
+	cases := make([]reportCase, 0,
+		len(result.CheckResults)+len(result.ListObjectsResults)+len(result.ListUsersResults))
+
+	for _, checkResult := range result.CheckResults {
+		got := NoValueString
+		if checkResult.Got != nil {
+			got = strconv.FormatBool(*checkResult.Got)
+		}
+
+		cases = append(cases, reportCase{
+			name: fmt.Sprintf("Check(user=%s,relation=%s,object=%s%s)",
+				checkResult.Request.User, checkResult.Request.Relation, checkResult.Request.Object,
+				requestContextSuffix(checkResult.Request.Context)),
+			passing:  checkResult.IsPassing(),
+			expected: checkResult.ExpectedString(),
+			got:      got,
+			err:      checkResult.Error,
+		})
+	}
+
+	for _, listObjectsResult := range result.ListObjectsResults {
+		got := NoValueString
+		if listObjectsResult.Got != nil {
+			got = fmt.Sprintf("%s", listObjectsResult.Got)
+		}
+
+		cases = append(cases, reportCase{
+			name: fmt.Sprintf("ListObjects(user=%s,relation=%s,type=%s%s)",
+				listObjectsResult.Request.User, listObjectsResult.Request.Relation, listObjectsResult.Request.Type,
+				requestContextSuffix(listObjectsResult.Request.Context)),
+			passing:  listObjectsResult.IsPassing(),
+			expected: fmt.Sprintf("%s", listObjectsResult.Expected),
+			got:      got,
+			err:      listObjectsResult.Error,
+		})
+	}
+
+	for _, listUsersResult := range result.ListUsersResults {
+		got := NoValueString
+		if listUsersResult.Got.Users != nil {
+			got = fmt.Sprintf("%+v", listUsersResult.Got)
+		}
+
+		userFilter := ""
+		if len(listUsersResult.Request.UserFilters) > 0 {
+			userFilter = fmt.Sprintf("%+v", listUsersResult.Request.UserFilters[0])
+		}
+
+		cases = append(cases, reportCase{
+			name: fmt.Sprintf("ListUsers(object=%+v,relation=%s,user_filter=%s%s)",
+				listUsersResult.Request.Object, listUsersResult.Request.Relation, userFilter,
+				requestContextSuffix(listUsersResult.Request.Context)),
+			passing:  listUsersResult.IsPassing(),
+			expected: fmt.Sprintf("%+v", listUsersResult.Expected),
+			got:      got,
+			err:      listUsersResult.Error,
+		})
+	}
+
+	return cases
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// WriteReport writes the results to w in the given format: JUnit XML with a testsuite per
+// test and a testcase per assertion, TAP with a test point per assertion, or JSON.
+func (test TestResults) WriteReport(w io.Writer, format ReportFormat) error {
+	switch format {
+	case ReportFormatJUnit:
+		return test.writeJUnitReport(w)
+	case ReportFormatTAP:
+		return test.writeTAPReport(w)
+	case ReportFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(test.Results); err != nil {
+			return fmt.Errorf("failed to write test report due to %w", err)
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %q is not a supported report format", clierrors.ErrInvalidFormat, format)
+	}
+}
+
+func (test TestResults) writeJUnitReport(w io.Writer) error {
+	report := junitTestSuites{TestSuites: []junitTestSuite{}}
+
+	for _, result := range test.Results {
+		suite := junitTestSuite{Name: result.Name, TestCases: []junitTestCase{}}
+
+		for _, reportCase := range result.reportCases() {
+			testCase := junitTestCase{Name: reportCase.name, ClassName: result.Name}
+
+			if !reportCase.passing {
+				message := reportCase.failureMessage()
+				testCase.Failure = &junitFailure{Message: message, Text: message}
+				suite.Failures++
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		suite.Tests = len(suite.TestCases)
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write test report due to %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write test report due to %w", err)
+	}
+
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write test report due to %w", err)
+	}
+
+	return nil
+}
+
+// writeTAPReport writes a TAP version 13 report. Failing test points are followed by
+// a YAML block holding the expected and actual values.
+func (test TestResults) writeTAPReport(w io.Writer) error {
+	var builder strings.Builder
+
+	total := 0
+	for _, result := range test.Results {
+		total += len(result.reportCases())
+	}
+
+	fmt.Fprintf(&builder, "TAP version 13\n1..%d\n", total)
+
+	index := 0
+
+	for _, result := range test.Results {
+		for _, reportCase := range result.reportCases() {
+			index++
+
+			status := "ok"
+			if !reportCase.passing {
+				status = "not ok"
+			}
+
+			fmt.Fprintf(&builder, "%s %d - %s: %s\n", status, index, result.Name, reportCase.name)
+
+			if reportCase.passing {
+				continue
+			}
+
+			builder.WriteString("  ---\n")
+			fmt.Fprintf(&builder, "  expected: %s\n", strconv.Quote(reportCase.expected))
+			fmt.Fprintf(&builder, "  got: %s\n", strconv.Quote(reportCase.got))
+
+			if reportCase.err != nil {
+				fmt.Fprintf(&builder, "  error: %s\n", strconv.Quote(reportCase.err.Error()))
+			}
+
+			builder.WriteString("  ...\n")
+		}
+	}
+
+	if _, err := io.WriteString(w, builder.String()); err != nil {
+		return fmt.Errorf("failed to write test report due to %w", err)
+	}
+
+	return nil
+}
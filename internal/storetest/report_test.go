@@ -0,0 +1,82 @@
+package storetest
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reportTestResults() TestResults {
+	allowed := true
+	denied := false
+
+	return TestResults{Results: []TestResult{{
+		Name: "viewers",
+		CheckResults: []ModelTestCheckSingleResult{
+			{
+				Request:  client.ClientCheckRequest{User: "user:anne", Relation: "viewer", Object: "document:1"},
+				Expected: true,
+				Got:      &allowed,
+			},
+			{
+				Request:  client.ClientCheckRequest{User: "user:beth", Relation: "viewer", Object: "document:1"},
+				Expected: true,
+				Got:      &denied,
+			},
+		},
+		ListObjectsResults: []ModelTestListObjectsSingleResult{{
+			Request:  client.ClientListObjectsRequest{User: "user:anne", Relation: "viewer", Type: "document"},
+			Expected: []string{"document:1"},
+			Got:      []string{"document:1"},
+		}},
+	}}}
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	require.NoError(t, reportTestResults().WriteReport(&buffer, ReportFormatJUnit))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1">
+  <testsuite name="viewers" tests="3" failures="1">
+    <testcase name="Check(user=user:anne,relation=viewer,object=document:1)" classname="viewers"></testcase>
+    <testcase name="Check(user=user:beth,relation=viewer,object=document:1)" classname="viewers">
+      <failure message="expected=true, got=false">expected=true, got=false</failure>
+    </testcase>
+    <testcase name="ListObjects(user=user:anne,relation=viewer,type=document)" classname="viewers"></testcase>
+  </testsuite>
+</testsuites>
+`, buffer.String())
+}
+
+func TestWriteTAPReport(t *testing.T) {
+	t.Parallel()
+
+	var buffer bytes.Buffer
+	require.NoError(t, reportTestResults().WriteReport(&buffer, ReportFormatTAP))
+
+	assert.Equal(t, `TAP version 13
+1..3
+ok 1 - viewers: Check(user=user:anne,relation=viewer,object=document:1)
+not ok 2 - viewers: Check(user=user:beth,relation=viewer,object=document:1)
+  ---
+  expected: "true"
+  got: "false"
+  ...
+ok 3 - viewers: ListObjects(user=user:anne,relation=viewer,type=document)
+`, buffer.String())
+}
+
+func TestReportFormatFromFileName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, ReportFormatJUnit, ReportFormatFromFileName("report.xml"))
+	assert.Equal(t, ReportFormatTAP, ReportFormatFromFileName("report.tap"))
+	assert.Equal(t, ReportFormatJSON, ReportFormatFromFileName("report.json"))
+	assert.Equal(t, ReportFormatNone, ReportFormatFromFileName("report.txt"))
+}
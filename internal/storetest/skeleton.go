@@ -0,0 +1,188 @@
+package storetest
+
+import (
+	"maps"
+	"slices"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+const (
+	// skeletonID is the id of every sample object, so that the sample tuples connect: the folder:1
+	// parent of document:1 is the folder that the folder tuples relate users to. A user of the type of
+	// the object has the id skeletonSelfID instead, so that a tuple never relates an object to itself.
+	skeletonID     = "1"
+	skeletonSelfID = "2"
+
+	skeletonTestName        = "starter"
+	skeletonTestDescription = "Replace the sample tuples with your own, and set the expected result of each assertion."
+)
+
+// NewStoreSkeleton returns a starter store for testing model, referencing it as modelFile. It has a
+// sample tuple for each directly assignable type of each relation, and a test with a check and a
+// list_objects assertion for each type#relation, that expect no access until the author fills them in.
+func NewStoreSkeleton(model *authorizationmodel.AuthzModel, storeName string, modelFile string) StoreData {
+	typeDefs := model.GetTypeDefinitions()
+	user := skeletonUser(typeDefs)
+	context := skeletonContext(model.GetConditions())
+
+	storeData := StoreData{
+		Name:      storeName,
+		ModelFile: modelFile,
+		Tuples:    []client.ClientContextualTupleKey{},
+		Tests: []ModelTest{{
+			Name:        skeletonTestName,
+			Description: skeletonTestDescription,
+			Check:       []ModelTestCheck{},
+		}},
+	}
+	test := &storeData.Tests[0]
+
+	for _, typeDef := range typeDefs {
+		relations := slices.Sorted(maps.Keys(typeDef.GetRelations()))
+		if len(relations) == 0 {
+			continue
+		}
+
+		object := typeDef.Type + ":" + skeletonID
+		metadata := typeDef.GetMetadata()
+
+		for _, relation := range relations {
+			relationMetadata := metadata.GetRelations()[relation]
+
+			for _, reference := range relationMetadata.GetDirectlyRelatedUserTypes() {
+				tuple := skeletonTuple(typeDef.Type, relation, reference)
+
+				// "user" and "user with condition" give the same tuple key, which can only be written once
+				if !slices.ContainsFunc(storeData.Tuples, func(existing client.ClientContextualTupleKey) bool {
+					return existing.User == tuple.User && existing.Relation == tuple.Relation && existing.Object == tuple.Object
+				}) {
+					storeData.Tuples = append(storeData.Tuples, tuple)
+				}
+			}
+		}
+
+		checkAssertions := map[string]bool{}
+		listObjectsAssertions := map[string][]string{}
+
+		for _, relation := range relations {
+			checkAssertions[relation] = false
+			listObjectsAssertions[relation] = []string{}
+		}
+
+		test.Check = append(test.Check, ModelTestCheck{
+			User:       user,
+			Object:     object,
+			Context:    context,
+			Assertions: checkAssertions,
+		})
+		test.ListObjects = append(test.ListObjects, ModelTestListObjects{
+			User:       user,
+			Type:       typeDef.Type,
+			Context:    context,
+			Assertions: listObjectsAssertions,
+		})
+	}
+
+	return storeData
+}
+
+// skeletonUser returns the user of the skeleton assertions: a user of the first type that is
+// directly assignable as a whole, such as "user" in "define viewer: [user]".
+func skeletonUser(typeDefs []openfga.TypeDefinition) string {
+	for _, typeDef := range typeDefs {
+		metadata := typeDef.GetMetadata()
+
+		for _, relation := range slices.Sorted(maps.Keys(metadata.GetRelations())) {
+			relationMetadata := metadata.GetRelations()[relation]
+
+			for _, reference := range relationMetadata.GetDirectlyRelatedUserTypes() {
+				if reference.Relation == nil && reference.Wildcard == nil {
+					return reference.Type + ":" + skeletonID
+				}
+			}
+		}
+	}
+
+	if len(typeDefs) == 0 {
+		return ""
+	}
+
+	return typeDefs[0].Type + ":" + skeletonID
+}
+
+func skeletonTuple(
+	objectType string,
+	relation string,
+	reference openfga.RelationReference,
+) client.ClientContextualTupleKey {
+	user := reference.Type + ":" + skeletonID
+	if reference.Type == objectType {
+		user = reference.Type + ":" + skeletonSelfID
+	}
+
+	switch {
+	case reference.Wildcard != nil:
+		user = reference.Type + ":*"
+	case reference.Relation != nil:
+		user += "#" + *reference.Relation
+	}
+
+	tuple := client.ClientContextualTupleKey{
+		User:     user,
+		Relation: relation,
+		Object:   objectType + ":" + skeletonID,
+	}
+
+	if reference.Condition != nil && *reference.Condition != "" {
+		tuple.Condition = &openfga.RelationshipCondition{Name: *reference.Condition}
+	}
+
+	return tuple
+}
+
+// skeletonContext returns a context setting each parameter of the conditions to a sample value of its
+// type, or nil if the model has no conditions.
+func skeletonContext(conditions *map[string]openfga.Condition) *map[string]any {
+	if conditions == nil || len(*conditions) == 0 {
+		return nil
+	}
+
+	context := map[string]any{}
+
+	for _, name := range slices.Sorted(maps.Keys(*conditions)) {
+		condition := (*conditions)[name]
+
+		for parameter, paramType := range condition.GetParameters() {
+			if _, ok := context[parameter]; !ok {
+				context[parameter] = sampleConditionValue(paramType.TypeName)
+			}
+		}
+	}
+
+	return &context
+}
+
+func sampleConditionValue(typeName openfga.TypeName) any {
+	switch typeName {
+	case openfga.TYPENAME_BOOL:
+		return false
+	case openfga.TYPENAME_INT, openfga.TYPENAME_UINT, openfga.TYPENAME_DOUBLE:
+		return 0
+	case openfga.TYPENAME_DURATION:
+		return "1m"
+	case openfga.TYPENAME_TIMESTAMP:
+		return "2024-01-01T00:00:00Z"
+	case openfga.TYPENAME_IPADDRESS:
+		return "127.0.0.1"
+	case openfga.TYPENAME_LIST:
+		return []any{}
+	case openfga.TYPENAME_MAP:
+		return map[string]any{}
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,109 @@
+package storetest
+
+import (
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+const skeletonModel = `model
+  schema 1.1
+
+type user
+
+type team
+  relations
+    define member: [user, team#member]
+
+type document
+  relations
+    define parent: [document]
+    define viewer: [user, user with in_range, user:*, team#member] or viewer from parent
+
+condition in_range(x: int) {
+  x < 100
+}`
+
+func TestNewStoreSkeleton(t *testing.T) {
+	t.Parallel()
+
+	model := authorizationmodel.AuthzModel{}
+	require.NoError(t, model.ReadFromDSLString(skeletonModel))
+
+	storeData := NewStoreSkeleton(&model, "docs", "model.fga")
+
+	assert.Equal(t, "docs", storeData.Name)
+	assert.Equal(t, "model.fga", storeData.ModelFile)
+	assert.Equal(t, []client.ClientContextualTupleKey{
+		{User: "user:1", Relation: "member", Object: "team:1"},
+		{User: "team:2#member", Relation: "member", Object: "team:1"},
+		{User: "document:2", Relation: "parent", Object: "document:1"},
+		{User: "user:1", Relation: "viewer", Object: "document:1"},
+		{User: "user:*", Relation: "viewer", Object: "document:1"},
+		{User: "team:1#member", Relation: "viewer", Object: "document:1"},
+	}, storeData.Tuples)
+
+	require.Len(t, storeData.Tests, 1)
+
+	context := &map[string]any{"x": 0}
+	assert.Equal(t, []ModelTestCheck{
+		{User: "user:1", Object: "team:1", Context: context, Assertions: map[string]bool{"member": false}},
+		{
+			User:       "user:1",
+			Object:     "document:1",
+			Context:    context,
+			Assertions: map[string]bool{"parent": false, "viewer": false},
+		},
+	}, storeData.Tests[0].Check)
+	assert.Equal(t, []ModelTestListObjects{
+		{User: "user:1", Type: "team", Context: context, Assertions: map[string][]string{"member": {}}},
+		{
+			User:       "user:1",
+			Type:       "document",
+			Context:    context,
+			Assertions: map[string][]string{"parent": {}, "viewer": {}},
+		},
+	}, storeData.Tests[0].ListObjects)
+}
+
+func TestNewStoreSkeletonRuns(t *testing.T) {
+	t.Parallel()
+
+	model := authorizationmodel.AuthzModel{}
+	require.NoError(t, model.ReadFromDSLString(skeletonModel))
+
+	storeData := NewStoreSkeleton(&model, "docs", "")
+	storeData.Model = skeletonModel
+
+	results, err := RunTests(t.Context(), nil, &storeData, authorizationmodel.ModelFormatFGA,
+		LocalServerConfig{MaxTypesPerAuthorizationModel: 100}, RunOptions{Parallel: 1})
+	require.NoError(t, err)
+	require.Len(t, results.Results, 1)
+
+	for _, result := range results.Results[0].CheckResults {
+		require.NoError(t, result.Error)
+	}
+
+	for _, result := range results.Results[0].ListObjectsResults {
+		require.NoError(t, result.Error)
+	}
+}
+
+func TestSkeletonContext(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, skeletonContext(nil))
+	assert.Equal(t, &map[string]any{"ip": "127.0.0.1", "at": "2024-01-01T00:00:00Z"},
+		skeletonContext(&map[string]openfga.Condition{"c": {
+			Name: "c",
+			Parameters: &map[string]openfga.ConditionParamTypeRef{
+				"ip": {TypeName: openfga.TYPENAME_IPADDRESS},
+				"at": {TypeName: openfga.TYPENAME_TIMESTAMP},
+			},
+		}}))
+}
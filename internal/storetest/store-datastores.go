@@ -0,0 +1,187 @@
+package storetest
+
+import (
+	"context"
+	"sync"
+
+	pb "github.com/openfga/api/proto/openfga/v1"
+	"github.com/openfga/openfga/pkg/storage"
+	"github.com/openfga/openfga/pkg/storage/memory"
+)
+
+// storeDatastores keeps the tuples, models, assertions and changes of each store in an in-memory
+// datastore of its own, dropped when the store is deleted. The memory datastore alone keeps them
+// after DeleteStore, so a server that runs tests for a long time, such as while watching test
+// files, would otherwise grow with every store it ran tests in. Stores themselves are kept in the
+// embedded datastore, so that listing stores still works.
+type storeDatastores struct {
+	storage.OpenFGADatastore
+
+	maxTypesPerAuthorizationModel int
+	mutex                         sync.Mutex
+	stores                        map[string]storage.OpenFGADatastore
+}
+
+func newStoreDatastores(maxTypesPerAuthorizationModel int) *storeDatastores {
+	return &storeDatastores{
+		OpenFGADatastore:              newMemoryDatastore(maxTypesPerAuthorizationModel),
+		maxTypesPerAuthorizationModel: maxTypesPerAuthorizationModel,
+		stores:                        map[string]storage.OpenFGADatastore{},
+	}
+}
+
+func newMemoryDatastore(maxTypesPerAuthorizationModel int) storage.OpenFGADatastore {
+	return memory.New(memory.WithMaxTypesPerAuthorizationModel(maxTypesPerAuthorizationModel))
+}
+
+// store returns the datastore of the store, creating it on first use.
+func (datastores *storeDatastores) store(store string) storage.OpenFGADatastore {
+	datastores.mutex.Lock()
+	defer datastores.mutex.Unlock()
+
+	datastore, ok := datastores.stores[store]
+	if !ok {
+		datastore = newMemoryDatastore(datastores.maxTypesPerAuthorizationModel)
+		datastores.stores[store] = datastore
+	}
+
+	return datastore
+}
+
+func (datastores *storeDatastores) DeleteStore(ctx context.Context, id string) error {
+	if err := datastores.OpenFGADatastore.DeleteStore(ctx, id); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	datastores.mutex.Lock()
+	defer datastores.mutex.Unlock()
+
+	if datastore, ok := datastores.stores[id]; ok {
+		datastore.Close()
+		delete(datastores.stores, id)
+	}
+
+	return nil
+}
+
+func (datastores *storeDatastores) Close() {
+	datastores.mutex.Lock()
+	defer datastores.mutex.Unlock()
+
+	for id, datastore := range datastores.stores {
+		datastore.Close()
+		delete(datastores.stores, id)
+	}
+
+	datastores.OpenFGADatastore.Close()
+}
+
+func (datastores *storeDatastores) Read(
+	ctx context.Context,
+	store string,
+	filter storage.ReadFilter,
+	options storage.ReadOptions,
+) (storage.TupleIterator, error) {
+	return datastores.store(store).Read(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadPage(
+	ctx context.Context,
+	store string,
+	filter storage.ReadFilter,
+	options storage.ReadPageOptions,
+) ([]*pb.Tuple, string, error) {
+	return datastores.store(store).ReadPage(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadUserTuple(
+	ctx context.Context,
+	store string,
+	filter storage.ReadUserTupleFilter,
+	options storage.ReadUserTupleOptions,
+) (*pb.Tuple, error) {
+	return datastores.store(store).ReadUserTuple(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadUsersetTuples(
+	ctx context.Context,
+	store string,
+	filter storage.ReadUsersetTuplesFilter,
+	options storage.ReadUsersetTuplesOptions,
+) (storage.TupleIterator, error) {
+	return datastores.store(store).ReadUsersetTuples(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadStartingWithUser(
+	ctx context.Context,
+	store string,
+	filter storage.ReadStartingWithUserFilter,
+	options storage.ReadStartingWithUserOptions,
+) (storage.TupleIterator, error) {
+	return datastores.store(store).ReadStartingWithUser(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) Write(
+	ctx context.Context,
+	store string,
+	deletes storage.Deletes,
+	writes storage.Writes,
+	options ...storage.TupleWriteOption,
+) error {
+	return datastores.store(store).Write(ctx, store, deletes, writes, options...) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadChanges(
+	ctx context.Context,
+	store string,
+	filter storage.ReadChangesFilter,
+	options storage.ReadChangesOptions,
+) ([]*pb.TupleChange, string, error) {
+	return datastores.store(store).ReadChanges(ctx, store, filter, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadAuthorizationModel(
+	ctx context.Context,
+	store string,
+	id string,
+) (*pb.AuthorizationModel, error) {
+	return datastores.store(store).ReadAuthorizationModel(ctx, store, id) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadAuthorizationModels(
+	ctx context.Context,
+	store string,
+	options storage.ReadAuthorizationModelsOptions,
+) ([]*pb.AuthorizationModel, string, error) {
+	return datastores.store(store).ReadAuthorizationModels(ctx, store, options) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) FindLatestAuthorizationModel(
+	ctx context.Context,
+	store string,
+) (*pb.AuthorizationModel, error) {
+	return datastores.store(store).FindLatestAuthorizationModel(ctx, store) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) WriteAuthorizationModel(
+	ctx context.Context,
+	store string,
+	model *pb.AuthorizationModel,
+) error {
+	return datastores.store(store).WriteAuthorizationModel(ctx, store, model) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) WriteAssertions(
+	ctx context.Context,
+	store, modelID string,
+	assertions []*pb.Assertion,
+) error {
+	return datastores.store(store).WriteAssertions(ctx, store, modelID, assertions) //nolint:wrapcheck
+}
+
+func (datastores *storeDatastores) ReadAssertions(
+	ctx context.Context,
+	store, modelID string,
+) ([]*pb.Assertion, error) {
+	return datastores.store(store).ReadAssertions(ctx, store, modelID) //nolint:wrapcheck
+}
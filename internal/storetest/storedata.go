@@ -38,6 +38,7 @@ var (
 	ErrUserRequired             = errors.New("must specify 'user' or 'users'")
 	ErrObjectAndObjectsConflict = errors.New("cannot contain both 'object' and 'objects'")
 	ErrObjectRequired           = errors.New("must specify 'object' or 'objects'")
+	ErrExpectedErrorConflict    = errors.New("cannot expect an 'error' or 'missing_parameters' along with 'allowed'")
 
 	errFailedProcessingTupleFiles = errors.New("failed to process one or more tuple files")
 )
@@ -49,12 +50,36 @@ type ModelTestCheck struct {
 	Objects    []string        `json:"objects,omitempty" yaml:"objects,omitempty"`
 	Context    *map[string]any `json:"context"           yaml:"context,omitempty"`
 	Assertions map[string]bool `json:"assertions"        yaml:"assertions"`
+	// Expectations assert more than whether the check is allowed, such as the error it fails with
+	Expectations map[string]ModelTestCheckExpectation `json:"expectations,omitempty" yaml:"expectations,omitempty"`
+	// Cases run the check once per case, each with its own variables and expected results
+	Cases []ModelTestCheckCase `json:"cases,omitempty" yaml:"cases,omitempty"`
+}
+
+// ModelTestCheckCase is a row of a table-driven check. Its variables replace the {{name}} placeholders
+// in the users, objects and context of the check, and its context, assertions and expectations are
+// added to the ones of the check, replacing them relation by relation.
+type ModelTestCheckCase struct {
+	Vars         map[string]string                    `json:"vars,omitempty"         yaml:"vars,omitempty"`
+	Context      *map[string]any                      `json:"context,omitempty"      yaml:"context,omitempty"`
+	Assertions   map[string]bool                      `json:"assertions,omitempty"   yaml:"assertions,omitempty"`
+	Expectations map[string]ModelTestCheckExpectation `json:"expectations,omitempty" yaml:"expectations,omitempty"`
+}
+
+// ModelTestCheckExpectation is what a check is expected to return: either an error, or whether it
+// is allowed. The fields that are not set are not asserted.
+type ModelTestCheckExpectation struct {
+	Allowed *bool `json:"allowed,omitempty" yaml:"allowed,omitempty"`
+	// Error is expected to be part of the message of the error the check fails with
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+	// MissingParameters are the condition parameters the check is expected to fail for lack of
+	MissingParameters []string `json:"missing_parameters,omitempty" yaml:"missing_parameters,omitempty"` //nolint:tagliatelle,lll
 }
 
 type ModelTestListObjects struct {
 	User       string              `json:"user"       yaml:"user"`
 	Type       string              `json:"type"       yaml:"type"`
-	Context    *map[string]any     `json:"context"    yaml:"context"`
+	Context    *map[string]any     `json:"context"    yaml:"context,omitempty"`
 	Assertions map[string][]string `json:"assertions" yaml:"assertions"`
 }
 
@@ -77,22 +102,33 @@ type ModelTest struct {
 	Check       []ModelTestCheck                  `json:"check"        yaml:"check"`
 	ListObjects []ModelTestListObjects            `json:"list_objects" yaml:"list_objects,omitempty"` //nolint:tagliatelle
 	ListUsers   []ModelTestListUsers              `json:"list_users"   yaml:"list_users,omitempty"`   //nolint:tagliatelle
+	// Use names the fixtures whose tuples are added to the tuples of the test
+	Use []string `json:"use" yaml:"use,omitempty"`
+	// TuplesRemove are global tuples that are left out when running the test
+	TuplesRemove []client.ClientContextualTupleKey `json:"tuples_remove" yaml:"tuples_remove,omitempty"` //nolint:tagliatelle,lll
 }
 
 type StoreData struct {
 	Name       string                            `json:"name"        yaml:"name"`
-	Model      string                            `json:"model"       yaml:"model"`
+	Model      string                            `json:"model"       yaml:"model,omitempty"`
 	ModelFile  string                            `json:"model_file"  yaml:"model_file,omitempty"` //nolint:tagliatelle
 	Tuples     []client.ClientContextualTupleKey `json:"tuples"      yaml:"tuples"`
 	TupleFile  string                            `json:"tuple_file"  yaml:"tuple_file,omitempty"`  //nolint:tagliatelle
 	TupleFiles []string                          `json:"tuple_files" yaml:"tuple_files,omitempty"` //nolint:tagliatelle
 	Tests      []ModelTest                       `json:"tests"       yaml:"tests"`
+	// Fixtures are named sets of tuples that tests can use
+	Fixtures map[string][]client.ClientContextualTupleKey `json:"fixtures" yaml:"fixtures,omitempty"`
+	// Extends is a store file whose model, tuples and fixtures are included in this store
+	Extends string `json:"extends" yaml:"extends,omitempty"`
 
 	// containBase is the directory that files referenced by this store must stay
 	// within, or "" when the caller opted out via --allow-external-files. A
 	// modular model defers reading its module files until the model is parsed, so
 	// the base has to travel with the store data to be enforced there.
 	containBase string
+
+	// extendedFiles are the files the store file that this store extends was read from.
+	extendedFiles []string
 }
 
 // ModelContainBase returns the directory that files referenced by a modular
@@ -101,6 +137,46 @@ func (storeData *StoreData) ModelContainBase() string {
 	return storeData.containBase
 }
 
+// ReferencedFiles returns the paths of the files the store file at fileName was read from: the
+// store file itself, its model file (along with the module files of a modular model), its
+// global and per-test tuple files, and the files of the store file it extends.
+func (storeData *StoreData) ReferencedFiles(fileName string, format authorizationmodel.ModelFormat) []string {
+	basePath := filepath.Dir(fileName)
+	files := []string{fileName}
+
+	addRef := func(ref string) {
+		if ref == "" {
+			return
+		}
+
+		if !filepath.IsAbs(ref) {
+			ref = filepath.Join(basePath, ref)
+		}
+
+		files = append(files, ref)
+	}
+
+	addRef(storeData.ModelFile)
+
+	if format == authorizationmodel.ModelFormatModular {
+		// the module files of a model that fails to parse are not known, the fga.mod file is still watched
+		moduleFiles, _ := authorizationmodel.ModularModelFiles(storeData.Model)
+		files = append(files, moduleFiles...)
+	}
+
+	addRef(storeData.TupleFile)
+
+	for _, tupleFile := range storeData.TupleFiles {
+		addRef(tupleFile)
+	}
+
+	for _, test := range storeData.Tests {
+		addRef(test.TupleFile)
+	}
+
+	return append(files, storeData.extendedFiles...)
+}
+
 // readRef reads a file referenced from within a store YAML, resolved against
 // basePath, and returns its contents along with the path used for
 // format detection.
@@ -238,6 +314,14 @@ func (storeData *StoreData) Validate() error {
 	var errs error
 
 	for _, test := range storeData.Tests {
+		for _, tuple := range test.TuplesRemove {
+			if !containsTupleKey(storeData.Tuples, tuple) {
+				err := fmt.Errorf("test %s tuple %s#%s@%s: %w",
+					test.Name, tuple.Object, tuple.Relation, tuple.User, ErrRemovedTupleNotFound)
+				errs = errors.Join(errs, err)
+			}
+		}
+
 		for index, check := range test.Check {
 			if check.User != "" && len(check.Users) > 0 {
 				err := fmt.Errorf("test %s check %d: %w", test.Name, index, ErrUserAndUsersConflict)
@@ -254,6 +338,17 @@ func (storeData *StoreData) Validate() error {
 				err := fmt.Errorf("test %s check %d: %w", test.Name, index, ErrObjectRequired)
 				errs = errors.Join(errs, err)
 			}
+
+			for _, expandedCheck := range expandCheckCases(check) {
+				for relation, expectation := range expandedCheck.Expectations {
+					if expectation.expectsError() && expectation.Allowed != nil {
+						err := fmt.Errorf("test %s check %d relation %s: %w", test.Name, index, relation, ErrExpectedErrorConflict)
+						errs = errors.Join(errs, err)
+					}
+				}
+			}
+
+			errs = errors.Join(errs, validateCheckCases(test.Name, index, check))
 		}
 	}
 
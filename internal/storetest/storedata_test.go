@@ -176,3 +176,32 @@ func TestModelTestCheckStructTagsOmitEmpty(t *testing.T) {
 	assert.NotEmpty(t, checkObjects.Objects)
 	assert.Len(t, checkObjects.Objects, 2)
 }
+
+func TestReferencedFilesModularModel(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeTempFile(t, dir, "fga.mod", "schema: '1.2'\ncontents:\n  - core.fga\n  - docs/documents.fga\n")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "docs"), 0o700))
+	writeTempFile(t, dir, "core.fga", "module core\n\ntype user\n")
+	writeTempFile(t, dir, "docs/documents.fga", "module docs\n\ntype document\n  relations\n    define viewer: [user]\n")
+	writeTempFile(t, dir, "tuples.yaml", "- user: user:anne\n  relation: viewer\n  object: document:1\n")
+	storeFile := writeTempFile(t, dir, "store.fga.yaml", `model_file: fga.mod
+tests:
+  - name: test
+    tuple_file: tuples.yaml
+    check: []
+`)
+
+	format, storeData, err := ReadFromFile(storeFile, "", false)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		storeFile,
+		filepath.Join(dir, "fga.mod"),
+		filepath.Join(dir, "core.fga"),
+		filepath.Join(dir, "docs", "documents.fga"),
+		filepath.Join(dir, "tuples.yaml"),
+	}, storeData.ReferencedFiles(storeFile, format))
+}
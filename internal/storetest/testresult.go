@@ -13,17 +13,32 @@ import (
 const NoValueString = "N/A"
 
 type ModelTestCheckSingleResult struct {
-	Request    client.ClientCheckRequest `json:"request"`
-	Expected   bool                      `json:"expected"`
-	Got        *bool                     `json:"got"`
-	Error      error                     `json:"error"`
-	TestResult bool                      `json:"test_result"`
+	Request  client.ClientCheckRequest `json:"request"`
+	Expected bool                      `json:"expected"`
+	// Expectation is set instead of Expected for the checks of the expectations of a test
+	Expectation *ModelTestCheckExpectation `json:"expectation,omitempty"`
+	Got         *bool                      `json:"got"`
+	Error       error                      `json:"error"`
+	TestResult  bool                       `json:"test_result"`
 }
 
 func (result ModelTestCheckSingleResult) IsPassing() bool {
+	if result.Expectation != nil {
+		return result.Expectation.isMetBy(result)
+	}
+
 	return result.Error == nil && *result.Got == result.Expected
 }
 
+// ExpectedString describes what the check is expected to return.
+func (result ModelTestCheckSingleResult) ExpectedString() string {
+	if result.Expectation != nil {
+		return result.Expectation.String()
+	}
+
+	return strconv.FormatBool(result.Expected)
+}
+
 type ModelTestListObjectsSingleResult struct {
 	Request    client.ClientListObjectsRequest `json:"request"`
 	Expected   []string                        `json:"expected"`
@@ -143,7 +158,7 @@ func buildCheckTestResults(
 				fmt.Fprintf(&checkResultsOutputSb126, ", context:%v", checkResult.Request.Context)
 			}
 
-			fmt.Fprintf(&checkResultsOutputSb126, "): expected=%t, got=%s", checkResult.Expected, got)
+			fmt.Fprintf(&checkResultsOutputSb126, "): expected=%s, got=%s", checkResult.ExpectedString(), got)
 
 			if checkResult.Error != nil {
 				fmt.Fprintf(&checkResultsOutputSb126, ", error=%v", checkResult.Error)
@@ -282,6 +297,8 @@ func buildTestResultOutput(result TestResult, totalCheckCount int, failedCheckCo
 
 type TestResults struct {
 	Results []TestResult `json:"results"`
+	// Coverage is only set when requested, and when the tests ran against a local model
+	Coverage *Coverage `json:"coverage,omitempty"`
 }
 
 // IsPassing - indicates whether a Test Suite has succeeded completely or has any failing tests.
@@ -7,6 +7,7 @@ import (
 	"github.com/openfga/openfga/pkg/server"
 
 	"github.com/openfga/cli/internal/authorizationmodel"
+	"github.com/openfga/cli/internal/requests"
 )
 
 type ModelTestOptions struct {
@@ -22,6 +23,14 @@ type LocalServerConfig struct {
 	MaxTypesPerAuthorizationModel int
 }
 
+// RunOptions controls how the tests of a store file are run.
+type RunOptions struct {
+	// Parallel is the number of tests run at a time
+	Parallel int
+	// Coverage collects which relations and condition outcomes of the model the tests exercise
+	Coverage bool
+}
+
 func RunTest(
 	ctx context.Context,
 	fgaClient *client.OpenFgaClient,
@@ -30,7 +39,7 @@ func RunTest(
 	globalTuples []client.ClientContextualTupleKey,
 	model *authorizationmodel.AuthzModel,
 ) (TestResult, error) {
-	testTuples := append(append([]client.ClientContextualTupleKey{}, globalTuples...), test.Tuples...)
+	testTuples := testTuples(test, globalTuples)
 
 	if model == nil {
 		return RunRemoteTest(ctx, fgaClient, test, testTuples), nil
@@ -39,12 +48,15 @@ func RunTest(
 	return RunLocalTest(ctx, fgaServer, test, testTuples, model)
 }
 
+// RunTests runs the tests of the store file, up to options.Parallel tests at a time. Each test runs
+// in its own store, so tests do not see each other's tuples, and results keep the order of the tests.
 func RunTests(
 	ctx context.Context,
 	fgaClient *client.OpenFgaClient,
 	storeData *StoreData,
 	format authorizationmodel.ModelFormat,
 	serverConfig LocalServerConfig,
+	options RunOptions,
 ) (TestResults, error) {
 	testResults := TestResults{}
 
@@ -59,20 +71,59 @@ func RunTests(
 
 	defer stopServerFn()
 
-	for _, test := range storeData.Tests {
+	return runTests(ctx, fgaClient, fgaServer, authModel, storeData, options)
+}
+
+func runTests(
+	ctx context.Context,
+	fgaClient *client.OpenFgaClient,
+	fgaServer *server.Server,
+	authModel *authorizationmodel.AuthzModel,
+	storeData *StoreData,
+	options RunOptions,
+) (TestResults, error) {
+	testResults := TestResults{}
+	results := make([]TestResult, len(storeData.Tests))
+
+	var coverage *Coverage
+
+	if options.Coverage && authModel != nil {
+		coverage = newCoverage(authModel)
+
+		recorder, err := newConditionRecorder(authModel, coverage)
+		if err != nil {
+			return testResults, err
+		}
+
+		ctx = withConditionRecorder(ctx, recorder)
+	}
+
+	err := requests.RunInParallel(len(storeData.Tests), options.Parallel, func(index int) error {
 		result, err := RunTest(
 			ctx,
 			fgaClient,
 			fgaServer,
-			test,
+			storeData.Tests[index],
 			storeData.Tuples,
 			authModel,
 		)
 		if err != nil {
-			return testResults, err
+			return err
 		}
 
-		testResults.Results = append(testResults.Results, result)
+		results[index] = result
+
+		return nil
+	})
+	if err != nil {
+		return testResults, err
+	}
+
+	testResults.Results = results
+
+	if coverage != nil {
+		coverage.addResults(results)
+		testResults.Coverage = coverage
 	}
 
 	return testResults, nil
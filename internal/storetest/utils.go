@@ -1,5 +1,7 @@
 package storetest
 
+import "github.com/openfga/go-sdk/client"
+
 func GetEffectiveUsers(checkTest ModelTestCheck) []string {
 	if len(checkTest.Users) > 0 {
 		return checkTest.Users
@@ -15,3 +17,28 @@ func GetEffectiveObjects(checkTest ModelTestCheck) []string {
 
 	return []string{checkTest.Object}
 }
+
+// GetCheckAssertions returns an assertion for each user, object and relation of the check tests.
+func GetCheckAssertions(checkTests []ModelTestCheck) []client.ClientAssertion {
+	assertions := make([]client.ClientAssertion, 0, len(checkTests))
+
+	for _, checkTest := range expandAllCheckCases(checkTests) {
+		users := GetEffectiveUsers(checkTest)
+		objects := GetEffectiveObjects(checkTest)
+
+		for _, user := range users {
+			for _, object := range objects {
+				for relation, expectation := range checkTest.Assertions {
+					assertions = append(assertions, client.ClientAssertion{
+						User:        user,
+						Relation:    relation,
+						Object:      object,
+						Expectation: expectation,
+					})
+				}
+			}
+		}
+	}
+
+	return assertions
+}
@@ -0,0 +1,179 @@
+package tuple
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/openfga/go-sdk/client"
+)
+
+// ErrCheckpointMismatch is returned when resuming from a checkpoint that was recorded for a different import.
+var ErrCheckpointMismatch = errors.New("checkpoint does not match this import")
+
+// ImportCheckpoint records which chunks of an import have completed, so that an
+// interrupted import can be resumed without sending them again. A chunk is the set
+// of tuples sent in a single write request, as returned by getImportChunk.
+type ImportCheckpoint struct {
+	Fingerprint       string `json:"fingerprint"`
+	MaxTuplesPerWrite int    `json:"max_tuples_per_write"`
+	TotalChunks       int    `json:"total_chunks"`
+	CompletedChunks   []int  `json:"completed_chunks"`
+
+	path      string
+	completed map[int]bool
+	mutex     sync.Mutex
+}
+
+// OpenImportCheckpoint returns the checkpoint stored at path. Unless resume is set, or if
+// there is no checkpoint at path yet, an empty checkpoint is returned and any existing
+// one is overwritten once the first chunk completes.
+func OpenImportCheckpoint(path string, resume bool) (*ImportCheckpoint, error) {
+	checkpoint := &ImportCheckpoint{path: path, completed: map[int]bool{}}
+
+	if !resume {
+		return checkpoint, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return checkpoint, nil
+		}
+
+		return nil, fmt.Errorf("failed to read checkpoint file %s due to %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint file %s due to %w", path, err)
+	}
+
+	for _, index := range checkpoint.CompletedChunks {
+		checkpoint.completed[index] = true
+	}
+
+	return checkpoint, nil
+}
+
+// IsCompleted reports whether the chunk at index was already imported.
+func (checkpoint *ImportCheckpoint) IsCompleted(index int) bool {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	return checkpoint.completed[index]
+}
+
+// prepare binds the checkpoint to an import. A checkpoint resumed from a file must have
+// been recorded for the same tuples split into chunks of the same size, otherwise the
+// chunk indices it holds would not refer to the same tuples.
+func (checkpoint *ImportCheckpoint) prepare(
+	maxTuplesPerWrite, totalChunks int,
+	writes []client.ClientTupleKey, deletes []client.ClientTupleKeyWithoutCondition,
+) error {
+	fingerprint, err := importFingerprint(writes, deletes)
+	if err != nil {
+		return err
+	}
+
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	if checkpoint.Fingerprint == "" {
+		checkpoint.Fingerprint = fingerprint
+		checkpoint.MaxTuplesPerWrite = maxTuplesPerWrite
+		checkpoint.TotalChunks = totalChunks
+
+		return nil
+	}
+
+	if checkpoint.Fingerprint != fingerprint {
+		return fmt.Errorf("%w: the tuples to import have changed", ErrCheckpointMismatch)
+	}
+
+	if checkpoint.MaxTuplesPerWrite != maxTuplesPerWrite {
+		return fmt.Errorf("%w: it was recorded with %d tuples per write, not %d",
+			ErrCheckpointMismatch, checkpoint.MaxTuplesPerWrite, maxTuplesPerWrite)
+	}
+
+	return nil
+}
+
+// complete marks the chunks as imported and saves the checkpoint.
+func (checkpoint *ImportCheckpoint) complete(indexes ...int) error {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	for _, index := range indexes {
+		if !checkpoint.completed[index] {
+			checkpoint.completed[index] = true
+			checkpoint.CompletedChunks = append(checkpoint.CompletedChunks, index)
+		}
+	}
+
+	sort.Ints(checkpoint.CompletedChunks)
+
+	return checkpoint.save()
+}
+
+// removeIfFinished removes the checkpoint file once every chunk of the import has completed.
+func (checkpoint *ImportCheckpoint) removeIfFinished() error {
+	checkpoint.mutex.Lock()
+	defer checkpoint.mutex.Unlock()
+
+	if len(checkpoint.CompletedChunks) < checkpoint.TotalChunks {
+		return nil
+	}
+
+	if err := os.Remove(checkpoint.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint file due to %w", err)
+	}
+
+	return nil
+}
+
+// save writes the checkpoint to a temporary file and renames it into place, so an
+// interrupted import never leaves a partially written checkpoint behind.
+func (checkpoint *ImportCheckpoint) save() error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to marshal checkpoint due to %w", err)
+	}
+
+	tmpPath := checkpoint.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("failed to write checkpoint file due to %w", err)
+	}
+
+	if err := os.Rename(tmpPath, checkpoint.path); err != nil {
+		return fmt.Errorf("failed to write checkpoint file due to %w", err)
+	}
+
+	return nil
+}
+
+func importFingerprint(writes []client.ClientTupleKey, deletes []client.ClientTupleKeyWithoutCondition) (string, error) {
+	hash := sha256.New()
+	encoder := json.NewEncoder(hash)
+
+	for _, write := range writes {
+		if err := encoder.Encode(write); err != nil {
+			return "", fmt.Errorf("failed to fingerprint tuples due to %w", err)
+		}
+	}
+
+	// separate the writes from the deletes, so moving a tuple between them changes the fingerprint
+	hash.Write([]byte{0})
+
+	for _, del := range deletes {
+		if err := encoder.Encode(del); err != nil {
+			return "", fmt.Errorf("failed to fingerprint tuples due to %w", err)
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
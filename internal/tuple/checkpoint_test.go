@@ -0,0 +1,139 @@
+package tuple
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	mockclient "github.com/openfga/cli/internal/mocks"
+)
+
+var errCheckpointTestWrite = errors.New("write failed")
+
+func checkpointTestWrites() []client.ClientTupleKey {
+	return []client.ClientTupleKey{
+		{User: "user:1", Relation: "viewer", Object: "doc:1"},
+		{User: "user:2", Relation: "viewer", Object: "doc:1"},
+		{User: "user:3", Relation: "viewer", Object: "doc:1"},
+		{User: "user:4", Relation: "viewer", Object: "doc:1"},
+		{User: "user:5", Relation: "viewer", Object: "doc:1"},
+	}
+}
+
+func expectCheckpointWrite(
+	mockCtrl *gomock.Controller, mockFgaClient *mockclient.MockSdkClient, writes []client.ClientTupleKey,
+) {
+	response := &client.ClientWriteResponse{}
+	for _, write := range writes {
+		response.Writes = append(response.Writes, client.ClientWriteRequestWriteResponse{
+			TupleKey: write,
+			Status:   client.SUCCESS,
+		})
+	}
+
+	mockRequest := mockclient.NewMockSdkClientWriteRequestInterface(mockCtrl)
+	mockRequest.EXPECT().Body(client.ClientWriteRequest{Writes: writes}).Return(mockRequest)
+	mockRequest.EXPECT().Options(gomock.Any()).Return(mockRequest)
+	mockRequest.EXPECT().Execute().Return(response, nil)
+	mockFgaClient.EXPECT().Write(gomock.Any()).Return(mockRequest)
+}
+
+func TestImportTuplesWithCheckpointResume(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	writes := checkpointTestWrites()
+	checkpointFile := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	// the first run completes the first batch of two chunks, then fails
+	checkpoint, err := OpenImportCheckpoint(checkpointFile, false)
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.prepare(1, len(writes), writes, nil))
+	require.NoError(t, checkpoint.complete(0, 1))
+
+	resumed, err := OpenImportCheckpoint(checkpointFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, resumed.CompletedChunks)
+
+	expectCheckpointWrite(mockCtrl, mockFgaClient, writes[2:4])
+	expectCheckpointWrite(mockCtrl, mockFgaClient, writes[4:])
+
+	response, err := ImportTuplesWithCheckpoint(t.Context(), mockFgaClient, 0, 0, 0, 1, 2,
+		client.ClientWriteRequest{Writes: writes}, client.ClientWriteOptions{}, resumed)
+	require.NoError(t, err)
+	assert.Equal(t, writes[2:], response.Successful)
+	assert.Equal(t, 2, response.SkippedCount)
+
+	// the checkpoint of a finished import is removed, so that resuming from it does not skip the import
+	assert.NoFileExists(t, checkpointFile)
+}
+
+func TestImportTuplesWithCheckpointKeepsUnfinishedCheckpoint(t *testing.T) {
+	t.Parallel()
+
+	mockCtrl := gomock.NewController(t)
+	mockFgaClient := mockclient.NewMockSdkClient(mockCtrl)
+
+	writes := checkpointTestWrites()
+	checkpointFile := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	checkpoint, err := OpenImportCheckpoint(checkpointFile, false)
+	require.NoError(t, err)
+
+	expectCheckpointWrite(mockCtrl, mockFgaClient, writes[0:2])
+
+	mockRequest := mockclient.NewMockSdkClientWriteRequestInterface(mockCtrl)
+	mockRequest.EXPECT().Body(gomock.Any()).Return(mockRequest)
+	mockRequest.EXPECT().Options(gomock.Any()).Return(mockRequest)
+	mockRequest.EXPECT().Execute().Return(nil, errCheckpointTestWrite)
+	mockFgaClient.EXPECT().Write(gomock.Any()).Return(mockRequest)
+
+	_, err = ImportTuplesWithCheckpoint(t.Context(), mockFgaClient, 0, 0, 0, 1, 2,
+		client.ClientWriteRequest{Writes: writes}, client.ClientWriteOptions{}, checkpoint)
+	require.ErrorIs(t, err, errCheckpointTestWrite)
+
+	saved, err := OpenImportCheckpoint(checkpointFile, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, saved.CompletedChunks)
+}
+
+func TestImportTuplesWithCheckpointMismatch(t *testing.T) {
+	t.Parallel()
+
+	writes := checkpointTestWrites()
+	checkpointFile := filepath.Join(t.TempDir(), "import.checkpoint")
+
+	checkpoint, err := OpenImportCheckpoint(checkpointFile, false)
+	require.NoError(t, err)
+	require.NoError(t, checkpoint.prepare(1, len(writes), writes, nil))
+	require.NoError(t, checkpoint.complete(0))
+
+	testcases := []struct {
+		name              string
+		maxTuplesPerWrite int
+		writes            []client.ClientTupleKey
+	}{
+		{name: "different tuples", maxTuplesPerWrite: 1, writes: writes[1:]},
+		{name: "different chunk size", maxTuplesPerWrite: 2, writes: writes},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			resumed, err := OpenImportCheckpoint(checkpointFile, true)
+			require.NoError(t, err)
+
+			_, err = ImportTuplesWithCheckpoint(t.Context(), nil, 0, 0, 0, test.maxTuplesPerWrite, 1,
+				client.ClientWriteRequest{Writes: test.writes}, client.ClientWriteOptions{}, resumed)
+			require.ErrorIs(t, err, ErrCheckpointMismatch)
+		})
+	}
+}
@@ -44,6 +44,8 @@ type failedWriteResponse struct {
 type ImportResponse struct {
 	Successful []client.ClientTupleKey `json:"successful"`
 	Failed     []failedWriteResponse   `json:"failed"`
+	// SkippedCount is the number of tuples not sent because a checkpoint recorded them as already imported.
+	SkippedCount int `json:"skipped_count"`
 }
 
 func validateImportParams(minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests int,
@@ -112,6 +114,19 @@ func ImportTuplesWithoutRampUp(ctx context.Context, fgaClient client.SdkClient,
 func ImportTuples(ctx context.Context, fgaClient client.SdkClient,
 	minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests int,
 	body client.ClientWriteRequest, opts client.ClientWriteOptions,
+) (*ImportResponse, error) {
+	return ImportTuplesWithCheckpoint(ctx, fgaClient,
+		minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests,
+		body, opts, nil)
+}
+
+// ImportTuplesWithCheckpoint works like ImportTuples, but records each chunk of tuples in checkpoint once
+// its write request completes, and skips the chunks the checkpoint already holds. Tuples that the server
+// rejected individually are reported as failed and their chunk still counts as completed.
+// A nil checkpoint disables checkpointing.
+func ImportTuplesWithCheckpoint(ctx context.Context, fgaClient client.SdkClient,
+	minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests int,
+	body client.ClientWriteRequest, opts client.ClientWriteOptions, checkpoint *ImportCheckpoint,
 ) (*ImportResponse, error) {
 	if err := validateImportParams(
 		minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests, body,
@@ -130,14 +145,49 @@ func ImportTuples(ctx context.Context, fgaClient client.SdkClient,
 		Conflict: opts.Conflict,
 	}
 
+	if checkpoint != nil {
+		numChunks := (len(body.Writes) + len(body.Deletes) + maxTuplesPerWrite - 1) / maxTuplesPerWrite
+		if err := checkpoint.prepare(maxTuplesPerWrite, numChunks, body.Writes, body.Deletes); err != nil {
+			return nil, err
+		}
+	}
+
 	// If RPS values are 0, then fallback to the previous way of importing
 	if minRPS == 0 || maxRPS == 0 {
-		return importTuplesWithoutRampUp(ctx, fgaClient, body, options)
+		if checkpoint == nil {
+			return importTuplesWithoutRampUp(ctx, fgaClient, body, options)
+		}
+
+		response, err := importTuplesWithoutRampUpWithCheckpoint(ctx, fgaClient,
+			maxTuplesPerWrite, maxParallelRequests, body, options, checkpoint)
+
+		return finishImportCheckpoint(response, err, checkpoint)
 	}
 
-	return importTuplesWithRampUp(ctx, fgaClient,
+	response, err := importTuplesWithRampUp(ctx, fgaClient,
 		minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests,
-		body, options)
+		body, options, checkpoint)
+	if checkpoint == nil {
+		return response, err
+	}
+
+	return finishImportCheckpoint(response, err, checkpoint)
+}
+
+// finishImportCheckpoint removes the checkpoint once every chunk was imported, so that resuming
+// from it later does not skip the whole import.
+func finishImportCheckpoint(
+	response *ImportResponse, err error, checkpoint *ImportCheckpoint,
+) (*ImportResponse, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkpoint.removeIfFinished(); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 func importTuplesWithoutRampUp(
@@ -157,6 +207,54 @@ func importTuplesWithoutRampUp(
 	return &result, nil
 }
 
+// importTuplesWithoutRampUpWithCheckpoint imports the chunks not yet recorded in the checkpoint. To keep the
+// request pattern of importTuplesWithoutRampUp, each SDK write call receives as many chunks as can be sent
+// in parallel, and the checkpoint is saved after each call returns.
+func importTuplesWithoutRampUpWithCheckpoint(ctx context.Context, fgaClient client.SdkClient,
+	maxTuplesPerWrite, maxParallelRequests int,
+	body client.ClientWriteRequest, options client.ClientWriteOptions, checkpoint *ImportCheckpoint,
+) (*ImportResponse, error) {
+	result := ImportResponse{}
+	numRequests := (len(body.Writes) + len(body.Deletes) + maxTuplesPerWrite - 1) / maxTuplesPerWrite
+
+	for batchStart := 0; batchStart < numRequests; batchStart += maxParallelRequests {
+		batch := client.ClientWriteRequest{}
+		batchIndexes := []int{}
+
+		for requestIndex := batchStart; requestIndex < min(batchStart+maxParallelRequests, numRequests); requestIndex++ {
+			writeChunk, deleteChunk := getImportChunk(requestIndex, maxTuplesPerWrite, body.Writes, body.Deletes)
+
+			if checkpoint.IsCompleted(requestIndex) {
+				result.SkippedCount += len(writeChunk) + len(deleteChunk)
+
+				continue
+			}
+
+			batch.Writes = append(batch.Writes, writeChunk...)
+			batch.Deletes = append(batch.Deletes, deleteChunk...)
+			batchIndexes = append(batchIndexes, requestIndex)
+		}
+
+		if len(batchIndexes) == 0 {
+			continue
+		}
+
+		response, err := importTuplesWithoutRampUp(ctx, fgaClient, batch, options)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Successful = append(result.Successful, response.Successful...)
+		result.Failed = append(result.Failed, response.Failed...)
+
+		if err := checkpoint.complete(batchIndexes...); err != nil {
+			return nil, err
+		}
+	}
+
+	return &result, nil
+}
+
 // importTuplesWithRampUp imports tuples to the store with rate limiting.
 // It receives a context, an FGA client, rate limiting parameters, and a write request body.
 // It returns a pointer to an ImportResponse and an error.
@@ -171,13 +269,14 @@ func importTuplesWithoutRampUp(
 // - maxParallelRequests: int - The maximum number of parallel requests.
 // - body: client.ClientWriteRequest - The write request body containing tuples to write or delete.
 // - options: client.ClientWriteOptions - The options for the write request.
+// - checkpoint: *ImportCheckpoint - Records completed chunks and skips those already completed (optional).
 //
 // Returns:
 // - *ImportResponse: A pointer to the ImportResponse containing successful and failed tuples.
 // - error: An error if the import fails.
 func importTuplesWithRampUp(ctx context.Context, fgaClient client.SdkClient,
 	minRPS, maxRPS, rampUpPeriodInSec, maxTuplesPerWrite, maxParallelRequests int,
-	body client.ClientWriteRequest, options client.ClientWriteOptions,
+	body client.ClientWriteRequest, options client.ClientWriteOptions, checkpoint *ImportCheckpoint,
 ) (*ImportResponse, error) {
 	result := ImportResponse{}
 	writes := body.Writes
@@ -194,7 +293,7 @@ func importTuplesWithRampUp(ctx context.Context, fgaClient client.SdkClient,
 		)
 	}
 
-	reqs := make([]func() error, numRequests)
+	reqs := make([]func() error, 0, numRequests)
 
 	var mutex sync.Mutex
 
@@ -203,12 +302,16 @@ func importTuplesWithRampUp(ctx context.Context, fgaClient client.SdkClient,
 		if len(writeChunk)+len(deleteChunk) == 0 {
 			fmt.Printf("Failed to import tuples due to empty write chunk index %v\n", requestIndex)
 
-			reqs[requestIndex] = func() error { return nil }
-
 			break
 		}
 
-		reqs[requestIndex] = func() error {
+		if checkpoint != nil && checkpoint.IsCompleted(requestIndex) {
+			result.SkippedCount += len(writeChunk) + len(deleteChunk)
+
+			continue
+		}
+
+		reqs = append(reqs, func() error {
 			request := fgaClient.Write(ctx).Body(client.ClientWriteRequest{
 				Writes:  writeChunk,
 				Deletes: deleteChunk,
@@ -235,8 +338,18 @@ func importTuplesWithRampUp(ctx context.Context, fgaClient client.SdkClient,
 
 			mutex.Unlock()
 
+			if checkpoint != nil {
+				if err := checkpoint.complete(requestIndex); err != nil {
+					if isDebug {
+						fmt.Printf("Failed to save import checkpoint due to error %v\n", err)
+					}
+
+					return err
+				}
+			}
+
 			return nil
-		}
+		})
 	}
 
 	if err := requests.RampUpAPIRequests(
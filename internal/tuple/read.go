@@ -9,7 +9,10 @@ import (
 	"github.com/openfga/go-sdk/client"
 )
 
-const DefaultReadPageSize int32 = 50
+const (
+	DefaultReadPageSize int32 = 50
+	MaxReadPageSize     int32 = 100
+)
 
 // ErrInvalidPageSize is returned when page size is outside valid range.
 var ErrInvalidPageSize = errors.New("pageSize must be between 1 and 100")
@@ -24,12 +27,38 @@ func Read(
 ) (
 	*openfga.ReadResponse, error,
 ) {
-	if pageSize < 1 || pageSize > 100 {
-		return nil, fmt.Errorf("%w: got %d", ErrInvalidPageSize, pageSize)
+	tuples := make([]openfga.Tuple, 0)
+
+	err := ReadPages(ctx, fgaClient, body, maxPages, pageSize, consistency, "",
+		func(page []openfga.Tuple, _ string) error {
+			tuples = append(tuples, page...)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return &openfga.ReadResponse{Tuples: tuples}, nil
+}
+
+// ReadPages reads tuples one page at a time starting from continuationToken, and calls handlePage
+// with each page along with the continuation token that resumes after it (empty on the last page).
+// It stops after maxPages pages (0 for no limit), after the last page, or when handlePage fails.
+func ReadPages(
+	ctx context.Context,
+	fgaClient client.SdkClient,
+	body *client.ClientReadRequest,
+	maxPages int,
+	pageSize int32,
+	consistency *openfga.ConsistencyPreference,
+	continuationToken string,
+	handlePage func(tuples []openfga.Tuple, continuationToken string) error,
+) error {
+	if pageSize < 1 || pageSize > MaxReadPageSize {
+		return fmt.Errorf("%w: got %d", ErrInvalidPageSize, pageSize)
 	}
 
-	tuples := make([]openfga.Tuple, 0)
-	continuationToken := ""
 	pageIndex := 0
 	options := client.ClientReadOptions{
 		PageSize: openfga.PtrInt32(pageSize),
@@ -44,10 +73,13 @@ func Read(
 
 		response, err := fgaClient.Read(ctx).Body(*body).Options(options).Execute()
 		if err != nil {
-			return nil, fmt.Errorf("failed to read tuples due to %w", err)
+			return fmt.Errorf("failed to read tuples due to %w", err)
+		}
+
+		if err := handlePage(response.Tuples, response.ContinuationToken); err != nil {
+			return err
 		}
 
-		tuples = append(tuples, response.Tuples...)
 		pageIndex++
 
 		if response.ContinuationToken == "" ||
@@ -58,7 +90,7 @@ func Read(
 		continuationToken = response.ContinuationToken
 	}
 
-	return &openfga.ReadResponse{Tuples: tuples}, nil
+	return nil
 }
 
 // TupleKeyToTupleKeyWithoutCondition converts a ClientTupleKey to a
@@ -0,0 +1,57 @@
+package tuple
+
+import (
+	"github.com/openfga/go-sdk/client"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+// InvalidTuple is a tuple that cannot be written with the model. Index is the position of the
+// tuple among the tuples of its file, starting at 1. Line is the line of the file the tuple is on,
+// for the file formats that have one tuple per line.
+type InvalidTuple struct {
+	Index  int                   `json:"index"`
+	Line   int                   `json:"line,omitempty"`
+	Tuple  client.ClientTupleKey `json:"tuple"`
+	Reason string                `json:"reason"`
+}
+
+// ValidationReport lists the tuples that cannot be written with the model.
+type ValidationReport struct {
+	TotalCount    int            `json:"total_count"`
+	ValidCount    int            `json:"valid_count"`
+	InvalidCount  int            `json:"invalid_count"`
+	InvalidTuples []InvalidTuple `json:"invalid_tuples"`
+}
+
+// Validate checks each tuple against the types, relations, directly related user types and
+// conditions of the model, without calling the server.
+func Validate(model *authorizationmodel.AuthzModel, tuples []client.ClientTupleKey) *ValidationReport {
+	return ValidateLines(model, tuples, nil)
+}
+
+// ValidateLines is Validate for tuples read from a file, where lines holds the line of the file each
+// tuple is on. lines can be nil when the lines of the tuples are not known.
+func ValidateLines(
+	model *authorizationmodel.AuthzModel,
+	tuples []client.ClientTupleKey,
+	lines []int,
+) *ValidationReport {
+	report := &ValidationReport{TotalCount: len(tuples), InvalidTuples: []InvalidTuple{}}
+
+	for index, tupleKey := range tuples {
+		if err := model.ValidateTuple(tupleKey); err != nil {
+			invalidTuple := InvalidTuple{Index: index + 1, Tuple: tupleKey, Reason: err.Error()}
+			if index < len(lines) {
+				invalidTuple.Line = lines[index]
+			}
+
+			report.InvalidTuples = append(report.InvalidTuples, invalidTuple)
+		}
+	}
+
+	report.InvalidCount = len(report.InvalidTuples)
+	report.ValidCount = report.TotalCount - report.InvalidCount
+
+	return report
+}
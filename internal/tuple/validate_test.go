@@ -0,0 +1,43 @@
+package tuple
+
+import (
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/authorizationmodel"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	model := authorizationmodel.AuthzModel{}
+	require.NoError(t, model.ReadFromDSLString(`model
+  schema 1.1
+
+type user
+
+type document
+  relations
+    define viewer: [user]
+`))
+
+	report := ValidateLines(&model, []client.ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{User: "user:anne", Relation: "editor", Object: "document:1"},
+		{User: "document:2", Relation: "viewer", Object: "document:1"},
+	}, []int{2, 3, 5})
+
+	assert.Equal(t, 3, report.TotalCount)
+	assert.Equal(t, 1, report.ValidCount)
+	assert.Equal(t, 2, report.InvalidCount)
+	require.Len(t, report.InvalidTuples, 2)
+	assert.Equal(t, 2, report.InvalidTuples[0].Index)
+	assert.Equal(t, 3, report.InvalidTuples[0].Line)
+	assert.Contains(t, report.InvalidTuples[0].Reason, `relation "editor" is not defined`)
+	assert.Equal(t, 3, report.InvalidTuples[1].Index)
+	assert.Equal(t, 5, report.InvalidTuples[1].Line)
+	assert.Contains(t, report.InvalidTuples[1].Reason, "document is not a directly assignable type")
+}
@@ -16,44 +16,44 @@ import (
 )
 
 func parseTuplesFromCSV(data []byte, tuples *[]client.ClientTupleKey) error {
-	reader := csv.NewReader(bytes.NewReader(data))
-
-	columns, err := readHeaders(reader)
+	reader, err := newCSVReader(bytes.NewReader(data), nil)
 	if err != nil {
 		return err
 	}
 
-	for index := 0; true; index++ {
+	for {
 		tuple, err := reader.Read()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
 
-			return fmt.Errorf("failed to read tuple from csv file: %w", err)
+			return err
 		}
 
-		tupleUserKey := tuple[columns.UserType] + ":" + tuple[columns.UserID]
-		if columns.UserRelation != -1 && tuple[columns.UserRelation] != "" {
-			tupleUserKey += "#" + tuple[columns.UserRelation]
-		}
+		*tuples = append(*tuples, tuple)
+	}
 
-		condition, err := parseConditionColumnsForRow(columns, tuple, index)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		tupleKey := client.ClientTupleKey{
-			User:      tupleUserKey,
-			Relation:  tuple[columns.Relation],
-			Object:    tuple[columns.ObjectType] + ":" + tuple[columns.ObjectID],
-			Condition: condition,
-		}
+func (columns *csvColumns) tupleFromRow(tuple []string, index int) (client.ClientTupleKey, error) {
+	tupleUserKey := tuple[columns.UserType] + ":" + tuple[columns.UserID]
+	if columns.UserRelation != -1 && tuple[columns.UserRelation] != "" {
+		tupleUserKey += "#" + tuple[columns.UserRelation]
+	}
 
-		*tuples = append(*tuples, tupleKey)
+	condition, err := parseConditionColumnsForRow(columns, tuple, index)
+	if err != nil {
+		return client.ClientTupleKey{}, err
 	}
 
-	return nil
+	return client.ClientTupleKey{
+		User:      tupleUserKey,
+		Relation:  tuple[columns.Relation],
+		Object:    tuple[columns.ObjectType] + ":" + tuple[columns.ObjectID],
+		Condition: condition,
+	}, nil
 }
 
 func parseConditionColumnsForRow(
@@ -113,6 +113,8 @@ func (columns *csvColumns) setHeaderIndex(headerName string, index int) error {
 		columns.ConditionName = index
 	case "condition_context":
 		columns.ConditionContext = index
+	case ReasonCSVHeader:
+		// written alongside failed tuples, carries no tuple data
 	default:
 		return fmt.Errorf("invalid header %q, valid headers are user_type,user_id,user_relation,relation,object_type,object_id,condition_name,condition_context", headerName) //nolint:err113,lll
 	}
@@ -150,7 +152,8 @@ func (columns *csvColumns) validate() error {
 	return nil
 }
 
-func readHeaders(reader *csv.Reader) (*csvColumns, error) {
+// readHeaders reads the header row, renaming the headers found in columnMapping to the column they map to.
+func readHeaders(reader *csv.Reader, columnMapping map[string]string) (*csvColumns, error) {
 	headers, err := reader.Read()
 	if err != nil {
 		return nil, fmt.Errorf("failed to read csv headers: %w", err)
@@ -167,7 +170,12 @@ func readHeaders(reader *csv.Reader) (*csvColumns, error) {
 		ConditionContext: -1,
 	}
 	for index, header := range headers {
-		err = columns.setHeaderIndex(strings.TrimSpace(header), index)
+		header = strings.TrimSpace(header)
+		if column, ok := columnMapping[header]; ok {
+			header = column
+		}
+
+		err = columns.setHeaderIndex(header, index)
 		if err != nil {
 			return nil, err
 		}
@@ -1,10 +1,10 @@
 package tuplefile
 
 import (
-	"bufio"
 	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"strings"
@@ -24,6 +24,44 @@ func ReadTupleFile(fileName string) ([]client.ClientTupleKey, error) {
 	return ParseTuples(fileName, data)
 }
 
+// ReadTupleFileLines reads the tuples of the file like ReadTupleFile, along with the line of the
+// file each of them is on, starting at 1. lines is nil for the json and yaml formats, where a
+// tuple can span several lines.
+func ReadTupleFileLines(fileName string) ([]client.ClientTupleKey, []int, error) {
+	file, err := os.Open(fileName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read file %q: %w", fileName, err)
+	}
+	defer file.Close()
+
+	reader, err := newReader(fileName, file, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		tuples []client.ClientTupleKey
+		lines  []int
+	)
+
+	for {
+		tuple, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return tuples, lines, nil
+			}
+
+			return nil, nil, err //nolint:wrapcheck
+		}
+
+		tuples = append(tuples, tuple)
+
+		if line, ok := reader.line(); ok {
+			lines = append(lines, line)
+		}
+	}
+}
+
 // ParseTuples parses already-read tuple file contents, using fileName only to
 // determine the format. Callers that must control how the file is read (for
 // example to contain the read to a directory) read the bytes themselves and
@@ -56,35 +94,18 @@ func ParseTuples(fileName string, data []byte) ([]client.ClientTupleKey, error)
 }
 
 func parseTuplesFromJSONL(data []byte, tuples *[]client.ClientTupleKey) error {
-	scanner := bufio.NewScanner(bytes.NewReader(data))
-	lineNum := 0
-
-	for scanner.Scan() {
-		lineNum++
-
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-
-		var tuple client.ClientTupleKey
+	reader := newJSONLReader(bytes.NewReader(data))
 
-		err := json.Unmarshal([]byte(line), &tuple)
+	for {
+		tuple, err := reader.Read()
 		if err != nil {
-			return fmt.Errorf("failed to read tuple from jsonl file on line %d: %w", lineNum, err)
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
 		}
 
 		*tuples = append(*tuples, tuple)
 	}
-
-	err := scanner.Err()
-	if err != nil {
-		return fmt.Errorf("failed to read jsonl file: %w", err)
-	}
-
-	if len(*tuples) == 0 {
-		return clierrors.EmptyTuplesFileError("jsonl") //nolint:wrapcheck
-	}
-
-	return nil
 }
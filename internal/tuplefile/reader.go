@@ -0,0 +1,266 @@
+package tuplefile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/openfga/go-sdk/client"
+	"gopkg.in/yaml.v3"
+
+	"github.com/openfga/cli/internal/clierrors"
+)
+
+// ErrInvalidColumnMapping is returned when a csv column mapping targets a column that is not in CSVHeaders.
+var ErrInvalidColumnMapping = errors.New("invalid csv column mapping")
+
+// Reader streams tuples from a tuple file.
+type Reader interface {
+	// Read returns the next tuple, or io.EOF once every tuple was read.
+	Read() (client.ClientTupleKey, error)
+}
+
+// NewReader returns a Reader for the format matching the extension of fileName, failing on the same
+// files ParseTuples fails on. The json, jsonl and csv formats are read one tuple at a time, while yaml
+// files are read at once, as a yaml sequence cannot be decoded an item at a time.
+//
+// columns maps the headers of a csv file to the columns in CSVHeaders, to read csv files with other
+// headers. It is ignored for the other formats.
+func NewReader(fileName string, r io.Reader, columns map[string]string) (Reader, error) {
+	reader, err := newReader(fileName, r, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	return reader, nil
+}
+
+func newReader(fileName string, r io.Reader, columns map[string]string) (*parseErrorReader, error) {
+	var (
+		reader Reader
+		err    error
+	)
+
+	switch path.Ext(fileName) {
+	case ".json":
+		reader, err = newJSONReader(r)
+	case ".yaml", ".yml":
+		reader, err = newYAMLReader(r, strings.TrimPrefix(path.Ext(fileName), "."))
+	case ".jsonl":
+		reader = newJSONLReader(r)
+	case ".csv":
+		reader, err = newCSVReader(r, columns)
+	default:
+		err = fmt.Errorf("unsupported file format %q", path.Ext(fileName)) //nolint:err113
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse input tuples: %w", err)
+	}
+
+	return &parseErrorReader{reader: reader}, nil
+}
+
+// lineReader is a Reader that knows the line of the file the last tuple it read is on, starting
+// at 1. Only the jsonl and csv readers do, as a json or yaml tuple can span several lines.
+type lineReader interface {
+	Line() int
+}
+
+// parseErrorReader prefixes read errors the same way ParseTuples does.
+type parseErrorReader struct {
+	reader Reader
+}
+
+func (r *parseErrorReader) line() (int, bool) {
+	reader, ok := r.reader.(lineReader)
+	if !ok {
+		return 0, false
+	}
+
+	return reader.Line(), true
+}
+
+func (r *parseErrorReader) Read() (client.ClientTupleKey, error) {
+	tuple, err := r.reader.Read()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return tuple, fmt.Errorf("failed to parse input tuples: %w", err)
+	}
+
+	return tuple, err
+}
+
+type jsonReader struct {
+	decoder *json.Decoder
+	count   int
+}
+
+func newJSONReader(r io.Reader) (*jsonReader, error) {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, clierrors.EmptyTuplesFileError("json") //nolint:wrapcheck
+		}
+
+		return nil, fmt.Errorf("failed to read json file: %w", err)
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("failed to read json file: expected an array of tuples, got %v", token) //nolint:err113
+	}
+
+	return &jsonReader{decoder: decoder}, nil
+}
+
+func (r *jsonReader) Read() (client.ClientTupleKey, error) {
+	var tuple client.ClientTupleKey
+
+	if !r.decoder.More() {
+		if r.count == 0 {
+			return tuple, clierrors.EmptyTuplesFileError("json") //nolint:wrapcheck
+		}
+
+		return tuple, io.EOF
+	}
+
+	if err := r.decoder.Decode(&tuple); err != nil {
+		return tuple, fmt.Errorf("failed to read tuple %d from json file: %w", r.count+1, err)
+	}
+
+	r.count++
+
+	return tuple, nil
+}
+
+type sliceReader struct {
+	tuples []client.ClientTupleKey
+}
+
+func (r *sliceReader) Read() (client.ClientTupleKey, error) {
+	if len(r.tuples) == 0 {
+		return client.ClientTupleKey{}, io.EOF
+	}
+
+	tuple := r.tuples[0]
+	r.tuples = r.tuples[1:]
+
+	return tuple, nil
+}
+
+func newYAMLReader(r io.Reader, extName string) (*sliceReader, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read yaml file: %w", err)
+	}
+
+	var tuples []client.ClientTupleKey
+
+	if err := yaml.Unmarshal(data, &tuples); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if len(tuples) == 0 {
+		return nil, clierrors.EmptyTuplesFileError(extName) //nolint:wrapcheck
+	}
+
+	return &sliceReader{tuples: tuples}, nil
+}
+
+type jsonlReader struct {
+	scanner *bufio.Scanner
+	lineNum int
+	count   int
+}
+
+func newJSONLReader(r io.Reader) *jsonlReader {
+	return &jsonlReader{scanner: bufio.NewScanner(r)}
+}
+
+func (r *jsonlReader) Line() int {
+	return r.lineNum
+}
+
+func (r *jsonlReader) Read() (client.ClientTupleKey, error) {
+	var tuple client.ClientTupleKey
+
+	for r.scanner.Scan() {
+		r.lineNum++
+
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if err := json.Unmarshal([]byte(line), &tuple); err != nil {
+			return tuple, fmt.Errorf("failed to read tuple from jsonl file on line %d: %w", r.lineNum, err)
+		}
+
+		r.count++
+
+		return tuple, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return tuple, fmt.Errorf("failed to read jsonl file: %w", err)
+	}
+
+	if r.count == 0 {
+		return tuple, clierrors.EmptyTuplesFileError("jsonl") //nolint:wrapcheck
+	}
+
+	return tuple, io.EOF
+}
+
+type csvReader struct {
+	reader  *csv.Reader
+	columns *csvColumns
+	index   int
+	line    int
+}
+
+func newCSVReader(r io.Reader, columnMapping map[string]string) (*csvReader, error) {
+	for header, column := range columnMapping {
+		if !slices.Contains(CSVHeaders, column) {
+			return nil, fmt.Errorf("%w: %q is mapped to %q, valid columns are %s",
+				ErrInvalidColumnMapping, header, column, strings.Join(CSVHeaders, ","))
+		}
+	}
+
+	reader := csv.NewReader(r)
+
+	columns, err := readHeaders(reader, columnMapping)
+	if err != nil {
+		return nil, err
+	}
+
+	return &csvReader{reader: reader, columns: columns}, nil
+}
+
+func (r *csvReader) Read() (client.ClientTupleKey, error) {
+	row, err := r.reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return client.ClientTupleKey{}, io.EOF
+		}
+
+		return client.ClientTupleKey{}, fmt.Errorf("failed to read tuple from csv file: %w", err)
+	}
+
+	index := r.index
+	r.index++
+	r.line, _ = r.reader.FieldPos(0)
+
+	return r.columns.tupleFromRow(row, index)
+}
+
+func (r *csvReader) Line() int {
+	return r.line
+}
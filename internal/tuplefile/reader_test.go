@@ -0,0 +1,156 @@
+package tuplefile
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openfga/cli/internal/clierrors"
+)
+
+func readAll(t *testing.T, reader Reader) ([]client.ClientTupleKey, error) {
+	t.Helper()
+
+	var tuples []client.ClientTupleKey
+
+	for {
+		tuple, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return tuples, nil
+		}
+
+		if err != nil {
+			return tuples, err
+		}
+
+		tuples = append(tuples, tuple)
+	}
+}
+
+func TestReaderMatchesParseTuples(t *testing.T) {
+	t.Parallel()
+
+	files := map[string]string{
+		"tuples.json": `[{"user":"user:anne","relation":"viewer","object":"document:1"},
+{"user":"group:eng#member","relation":"editor","object":"document:2",
+ "condition":{"name":"in_range","context":{"x":"1"}}}]`,
+		"tuples.yaml": `- user: user:anne
+  relation: viewer
+  object: document:1
+- user: group:eng#member
+  relation: editor
+  object: document:2
+  condition:
+    name: in_range
+    context:
+      x: "1"
+`,
+		"tuples.jsonl": `{"user":"user:anne","relation":"viewer","object":"document:1"}
+
+{"user":"group:eng#member","relation":"editor","object":"document:2","condition":{"name":"in_range","context":{"x":"1"}}}
+`,
+		"tuples.csv": `user_type,user_id,user_relation,relation,object_type,object_id,condition_name,condition_context
+user,anne,,viewer,document,1,,
+group,eng,member,editor,document,2,in_range,"{""x"":""1""}"
+`,
+	}
+
+	for fileName, data := range files {
+		t.Run(fileName, func(t *testing.T) {
+			t.Parallel()
+
+			expected, err := ParseTuples(fileName, []byte(data))
+			require.NoError(t, err)
+
+			reader, err := NewReader(fileName, strings.NewReader(data), nil)
+			require.NoError(t, err)
+
+			tuples, err := readAll(t, reader)
+			require.NoError(t, err)
+			assert.Equal(t, expected, tuples)
+		})
+	}
+}
+
+func TestReaderEmptyFiles(t *testing.T) {
+	t.Parallel()
+
+	for _, fileName := range []string{"tuples.json", "tuples.jsonl"} {
+		reader, err := NewReader(fileName, strings.NewReader(""), nil)
+		if err == nil {
+			_, err = readAll(t, reader)
+		}
+
+		require.ErrorIs(t, err, clierrors.ErrEmptyTuplesFile, fileName)
+	}
+}
+
+func TestReaderCSVColumnMapping(t *testing.T) {
+	t.Parallel()
+
+	data := `subject,subject_type,relation,resource_type,resource
+anne,user,viewer,document,1
+`
+	columns := map[string]string{
+		"subject":       "user_id",
+		"subject_type":  "user_type",
+		"resource_type": "object_type",
+		"resource":      "object_id",
+	}
+
+	reader, err := NewReader("tuples.csv", strings.NewReader(data), columns)
+	require.NoError(t, err)
+
+	tuples, err := readAll(t, reader)
+	require.NoError(t, err)
+	assert.Equal(t, []client.ClientTupleKey{{User: "user:anne", Relation: "viewer", Object: "document:1"}}, tuples)
+
+	_, err = NewReader("tuples.csv", strings.NewReader(data), map[string]string{"subject": "principal"})
+	require.ErrorIs(t, err, ErrInvalidColumnMapping)
+}
+
+func TestReadTupleFileLines(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	files := map[string]struct {
+		content string
+		lines   []int
+	}{
+		"tuples.csv": {
+			content: "user_type,user_id,relation,object_type,object_id,condition_name,condition_context\n" +
+				"user,anne,viewer,document,1,,\n" +
+				"user,beth,viewer,document,2,in_range,\"{\n\"\"x\"\": 1}\"\n" +
+				"user,carl,viewer,document,3,,\n",
+			lines: []int{2, 3, 5},
+		},
+		"tuples.jsonl": {
+			content: `{"user":"user:anne","relation":"viewer","object":"document:1"}
+
+{"user":"user:beth","relation":"viewer","object":"document:2"}
+`,
+			lines: []int{1, 3},
+		},
+		"tuples.json": {
+			content: `[{"user":"user:anne","relation":"viewer","object":"document:1"}]`,
+		},
+	}
+
+	for name, file := range files {
+		fileName := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(fileName, []byte(file.content), 0o600))
+
+		tuples, lines, err := ReadTupleFileLines(fileName)
+		require.NoError(t, err, name)
+		assert.Len(t, tuples, max(len(file.lines), 1), name)
+		assert.Equal(t, file.lines, lines, name)
+	}
+}
@@ -0,0 +1,357 @@
+package tuplefile
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnsupportedStreamFormat is returned when tuples cannot be streamed to a file of the given format.
+var ErrUnsupportedStreamFormat = errors.New("tuples can only be streamed to .jsonl or .csv files")
+
+// ReasonCSVHeader is the column holding the failure reason in a file written by WriteFailedTuples.
+// It is ignored when reading, so that the file can be imported again.
+const ReasonCSVHeader = "reason"
+
+// CSVHeaders are the columns written to, and recognized when reading, a csv tuple file.
+var CSVHeaders = []string{
+	"user_type",
+	"user_id",
+	"user_relation",
+	"relation",
+	"object_type",
+	"object_id",
+	"condition_name",
+	"condition_context",
+}
+
+// Writer streams tuples to a tuple file that ParseTuples can read back.
+type Writer interface {
+	Write(tuple client.ClientTupleKey) error
+	// Flush writes any buffered tuples to the underlying io.Writer.
+	Flush() error
+}
+
+// NewWriter returns a Writer for the format matching the extension of fileName. Only the
+// line based formats (jsonl and csv) can be streamed. Set withHeader to false when
+// appending to a csv file that already has its header row.
+func NewWriter(fileName string, w io.Writer, withHeader bool) (Writer, error) {
+	if err := ValidateStreamFormat(fileName); err != nil {
+		return nil, err
+	}
+
+	buffered := bufio.NewWriter(w)
+
+	switch path.Ext(fileName) {
+	case ".csv":
+		writer := &csvWriter{buffered: buffered, writer: csv.NewWriter(buffered)}
+		if withHeader {
+			if err := writer.writer.Write(CSVHeaders); err != nil {
+				return nil, fmt.Errorf("failed to write csv header: %w", err)
+			}
+		}
+
+		return writer, nil
+	default:
+		return &jsonlWriter{writer: buffered}, nil
+	}
+}
+
+// ValidateStreamFormat returns ErrUnsupportedStreamFormat if NewWriter cannot write to fileName.
+func ValidateStreamFormat(fileName string) error {
+	switch path.Ext(fileName) {
+	case ".jsonl", ".csv":
+		return nil
+	default:
+		return fmt.Errorf("%w: %q", ErrUnsupportedStreamFormat, fileName)
+	}
+}
+
+// NewDocumentWriter returns a Writer for any format that ParseTuples reads, matching the extension
+// of fileName. The jsonl and csv formats are written as by NewWriter. The json and yaml formats are
+// written as a single array of tuples, which Flush closes, so Flush must only be called once, after
+// the last tuple.
+func NewDocumentWriter(fileName string, w io.Writer) (Writer, error) {
+	if err := ValidateDocumentFormat(fileName); err != nil {
+		return nil, err
+	}
+
+	switch path.Ext(fileName) {
+	case ".json":
+		return &jsonWriter{writer: bufio.NewWriter(w)}, nil
+	case ".yaml", ".yml":
+		return &yamlWriter{writer: bufio.NewWriter(w)}, nil
+	default:
+		return NewWriter(fileName, w, true)
+	}
+}
+
+// ValidateDocumentFormat returns an error if NewDocumentWriter cannot write to fileName.
+func ValidateDocumentFormat(fileName string) error {
+	switch path.Ext(fileName) {
+	case ".json", ".yaml", ".yml", ".jsonl", ".csv":
+		return nil
+	default:
+		return fmt.Errorf("unsupported file format %q", path.Ext(fileName)) //nolint:err113
+	}
+}
+
+type jsonWriter struct {
+	writer *bufio.Writer
+	count  int
+}
+
+func (w *jsonWriter) Write(tuple client.ClientTupleKey) error {
+	item, err := json.MarshalIndent(tuple, "  ", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal tuple: %w", err)
+	}
+
+	separator := ",\n  "
+	if w.count == 0 {
+		separator = "[\n  "
+	}
+
+	if _, err := w.writer.WriteString(separator); err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	if _, err := w.writer.Write(item); err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	w.count++
+
+	return nil
+}
+
+func (w *jsonWriter) Flush() error {
+	closing := "\n]\n"
+	if w.count == 0 {
+		closing = "[]\n"
+	}
+
+	if _, err := w.writer.WriteString(closing); err != nil {
+		return fmt.Errorf("failed to write tuples: %w", err)
+	}
+
+	return w.writer.Flush() //nolint:wrapcheck
+}
+
+type yamlWriter struct {
+	writer *bufio.Writer
+	count  int
+}
+
+func (w *yamlWriter) Write(tuple client.ClientTupleKey) error {
+	// a sequence of one tuple marshals to the item as it appears in the full sequence
+	item, err := yaml.Marshal([]client.ClientTupleKey{tuple})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tuple: %w", err)
+	}
+
+	if _, err := w.writer.Write(item); err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	w.count++
+
+	return nil
+}
+
+func (w *yamlWriter) Flush() error {
+	if w.count == 0 {
+		if _, err := w.writer.WriteString("[]\n"); err != nil {
+			return fmt.Errorf("failed to write tuples: %w", err)
+		}
+	}
+
+	return w.writer.Flush() //nolint:wrapcheck
+}
+
+type jsonlWriter struct {
+	writer *bufio.Writer
+}
+
+func (w *jsonlWriter) Write(tuple client.ClientTupleKey) error {
+	line, err := json.Marshal(tuple)
+	if err != nil {
+		return fmt.Errorf("failed to marshal tuple: %w", err)
+	}
+
+	if _, err := w.writer.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	return nil
+}
+
+func (w *jsonlWriter) Flush() error {
+	return w.writer.Flush() //nolint:wrapcheck
+}
+
+type csvWriter struct {
+	buffered *bufio.Writer
+	writer   *csv.Writer
+}
+
+func (w *csvWriter) Write(tuple client.ClientTupleKey) error {
+	record, err := TupleToCSVRecord(tuple)
+	if err != nil {
+		return err
+	}
+
+	if err := w.writer.Write(record); err != nil {
+		return fmt.Errorf("failed to write tuple: %w", err)
+	}
+
+	return nil
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+
+	return w.buffered.Flush() //nolint:wrapcheck
+}
+
+// TupleToCSVRecord converts a tuple to a csv row with the columns in CSVHeaders.
+func TupleToCSVRecord(tuple client.ClientTupleKey) ([]string, error) {
+	userType, userID, _ := strings.Cut(tuple.User, ":")
+	userID, userRelation, _ := strings.Cut(userID, "#")
+	objectType, objectID, _ := strings.Cut(tuple.Object, ":")
+
+	conditionName := ""
+	conditionContext := ""
+
+	if tuple.Condition != nil {
+		conditionName = tuple.Condition.Name
+
+		if tuple.Condition.Context != nil {
+			contextJSON, err := json.Marshal(tuple.Condition.Context)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert condition context to csv: %w", err)
+			}
+
+			conditionContext = string(contextJSON)
+		}
+	}
+
+	return []string{
+		userType,
+		userID,
+		userRelation,
+		tuple.Relation,
+		objectType,
+		objectID,
+		conditionName,
+		conditionContext,
+	}, nil
+}
+
+// FailedTuple is a tuple that could not be written, along with the reason it failed.
+type FailedTuple struct {
+	Tuple  client.ClientTupleKey
+	Reason string
+}
+
+type failedTupleRecord struct {
+	User      string                         `json:"user"                yaml:"user"`
+	Relation  string                         `json:"relation"            yaml:"relation"`
+	Object    string                         `json:"object"              yaml:"object"`
+	Condition *openfga.RelationshipCondition `json:"condition,omitempty" yaml:"condition,omitempty"`
+	Reason    string                         `json:"reason"              yaml:"reason"`
+}
+
+// WriteFailedTuples writes the failed tuples to w in the format matching the extension of fileName,
+// with the failure reason as an extra field (or column, for csv). ParseTuples ignores the reason, so
+// the file can be imported again once the tuples are fixed.
+func WriteFailedTuples(fileName string, w io.Writer, tuples []FailedTuple) error {
+	if path.Ext(fileName) == ".csv" {
+		return writeFailedTuplesAsCSV(w, tuples)
+	}
+
+	records := make([]failedTupleRecord, 0, len(tuples))
+	for _, failed := range tuples {
+		records = append(records, failedTupleRecord{
+			User:      failed.Tuple.User,
+			Relation:  failed.Tuple.Relation,
+			Object:    failed.Tuple.Object,
+			Condition: failed.Tuple.Condition,
+			Reason:    failed.Reason,
+		})
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch path.Ext(fileName) {
+	case ".json":
+		data, err = json.MarshalIndent(records, "", "  ")
+		data = append(data, '\n')
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(records)
+	case ".jsonl":
+		encoder := json.NewEncoder(w)
+		for _, record := range records {
+			if err := encoder.Encode(record); err != nil {
+				return fmt.Errorf("failed to write failed tuples: %w", err)
+			}
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported file format %q", path.Ext(fileName)) //nolint:err113
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal failed tuples: %w", err)
+	}
+
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write failed tuples: %w", err)
+	}
+
+	return nil
+}
+
+func writeFailedTuplesAsCSV(w io.Writer, tuples []FailedTuple) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write(append(append([]string{}, CSVHeaders...), ReasonCSVHeader)); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, failed := range tuples {
+		record, err := TupleToCSVRecord(failed.Tuple)
+		if err != nil {
+			return err
+		}
+
+		if err := writer.Write(append(record, failed.Reason)); err != nil {
+			return fmt.Errorf("failed to write failed tuples: %w", err)
+		}
+	}
+
+	writer.Flush()
+
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write failed tuples: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package tuplefile
+
+import (
+	"bytes"
+	"testing"
+
+	openfga "github.com/openfga/go-sdk"
+	"github.com/openfga/go-sdk/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tuples := []client.ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{User: "group:eng#member", Relation: "editor", Object: "document:2"},
+		{
+			User:     "user:beth",
+			Relation: "viewer",
+			Object:   "document:3",
+			Condition: &openfga.RelationshipCondition{
+				Name:    "in_range",
+				Context: &map[string]any{"x": float64(1)},
+			},
+		},
+	}
+
+	for _, fileName := range []string{"tuples.jsonl", "tuples.csv"} {
+		t.Run(fileName, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			writer, err := NewWriter(fileName, &buffer, true)
+			require.NoError(t, err)
+
+			for _, tuple := range tuples {
+				require.NoError(t, writer.Write(tuple))
+			}
+
+			require.NoError(t, writer.Flush())
+
+			parsed, err := ParseTuples(fileName, buffer.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, tuples, parsed)
+		})
+	}
+}
+
+func TestNewWriterUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewWriter("tuples.yaml", &bytes.Buffer{}, true)
+	require.ErrorIs(t, err, ErrUnsupportedStreamFormat)
+}
+
+func TestWriteFailedTuples(t *testing.T) {
+	t.Parallel()
+
+	failed := []FailedTuple{
+		{
+			Tuple:  client.ClientTupleKey{User: "user:anne", Relation: "viewer", Object: "document:1"},
+			Reason: "type 'document' not found",
+		},
+		{
+			Tuple: client.ClientTupleKey{
+				User:     "group:eng#member",
+				Relation: "editor",
+				Object:   "document:2",
+				Condition: &openfga.RelationshipCondition{
+					Name:    "in_range",
+					Context: &map[string]any{"ip": "10.0.0.1"},
+				},
+			},
+			Reason: "condition 'in_range' not found",
+		},
+	}
+
+	for _, fileName := range []string{"failed.csv", "failed.jsonl", "failed.json", "failed.yaml"} {
+		t.Run(fileName, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			require.NoError(t, WriteFailedTuples(fileName, &buffer, failed))
+			assert.Contains(t, buffer.String(), "type 'document' not found")
+
+			parsed, err := ParseTuples(fileName, buffer.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, []client.ClientTupleKey{failed[0].Tuple, failed[1].Tuple}, parsed)
+		})
+	}
+}
+
+func TestDocumentWriterRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	tuples := []client.ClientTupleKey{
+		{User: "user:anne", Relation: "viewer", Object: "document:1"},
+		{
+			User:     "group:eng#member",
+			Relation: "editor",
+			Object:   "document:2",
+			Condition: &openfga.RelationshipCondition{
+				Name:    "in_range",
+				Context: &map[string]any{"ip": "10.0.0.1"},
+			},
+		},
+	}
+
+	for _, fileName := range []string{"tuples.json", "tuples.yaml", "tuples.jsonl", "tuples.csv"} {
+		t.Run(fileName, func(t *testing.T) {
+			t.Parallel()
+
+			var buffer bytes.Buffer
+
+			writer, err := NewDocumentWriter(fileName, &buffer)
+			require.NoError(t, err)
+
+			for _, tuple := range tuples {
+				require.NoError(t, writer.Write(tuple))
+			}
+
+			require.NoError(t, writer.Flush())
+
+			parsed, err := ParseTuples(fileName, buffer.Bytes())
+			require.NoError(t, err)
+			assert.Equal(t, tuples, parsed)
+		})
+	}
+}